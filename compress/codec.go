@@ -0,0 +1,80 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package compress
+
+import (
+	"errors"
+)
+
+//goland:noinspection GoSnakeCaseUsage
+const (
+	DEFLATE = "deflate" // RFC 1951
+	ZLIB    = "zlib"    // RFC 1950
+	ZSTD    = "zstd"    // RFC 8878, not built-in
+)
+
+var (
+	ErrCodecNotSupported = errors.New("compress: codec not supported")
+	ErrSizeLimit         = errors.New("compress: decompressed size exceeds the limit")
+)
+
+// Codec compresses/decompresses data in one format
+type Codec interface {
+
+	// Algorithm returns the codec name
+	Algorithm() string
+
+	// Compress returns the compressed data
+	//
+	// Returns: nil on error
+	Compress(data []byte) []byte
+
+	// Decompress restores the data, but stops with ErrSizeLimit
+	// as soon as the output would exceed the limit (zip bomb)
+	Decompress(data []byte, limit int) ([]byte, error)
+}
+
+//
+//  Codec Registry
+//
+
+var sharedCodecs = make(map[string]Codec, 4)
+
+// SetCodec registers a codec for the algorithm name,
+// e.g.: SetCodec(ZSTD, codec) with a zstd library
+func SetCodec(algorithm string, codec Codec) {
+	sharedCodecs[algorithm] = codec
+}
+
+// GetCodec returns the codec for the algorithm name
+func GetCodec(algorithm string) Codec {
+	return sharedCodecs[algorithm]
+}
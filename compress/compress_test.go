@@ -0,0 +1,171 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package compress_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/dimchat/plugins-go/ext"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/compress"
+)
+
+func init() {
+	ext.ExtensionLoader{}.Load()
+	ext.PluginLoader{}.Load()
+}
+
+func textPayload() []byte {
+	return []byte(strings.Repeat(`{"type":1,"text":"Hello world! 你好，世界！"}`, 100))
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	data := textPayload()
+	for _, algorithm := range []string{DEFLATE, ZLIB} {
+		codec := GetCodec(algorithm)
+		if codec == nil || codec.Algorithm() != algorithm {
+			t.Fatalf("codec not registered: %s", algorithm)
+		}
+		compressed := codec.Compress(data)
+		if compressed == nil || len(compressed) >= len(data) {
+			t.Errorf("%s: %d -> %d bytes", algorithm, len(data), len(compressed))
+		}
+		out, err := codec.Decompress(compressed, len(data))
+		if err != nil || !bytes.Equal(out, data) {
+			t.Errorf("%s: round trip failed: %v", algorithm, err)
+		}
+		// one byte less than the original
+		if _, err = codec.Decompress(compressed, len(data)-1); err != ErrSizeLimit {
+			t.Errorf("%s: limit not checked: %v", algorithm, err)
+		}
+		// corrupted
+		if _, err = codec.Decompress(compressed[:len(compressed)/2], len(data)); err == nil {
+			t.Errorf("%s: truncated data accepted", algorithm)
+		}
+	}
+}
+
+func TestPayloadCompressor(t *testing.T) {
+	data := textPayload()
+	pc := NewPayloadCompressor(ZLIB)
+	extra := NewMap()
+	compressed := pc.Compress(data, extra)
+	if extra["compression"] != ZLIB || len(compressed) >= len(data) {
+		t.Fatalf("not compressed: %v, %d bytes", extra, len(compressed))
+	}
+	out, err := pc.Decompress(compressed, extra)
+	if err != nil || !bytes.Equal(out, data) {
+		t.Errorf("round trip failed: %v", err)
+	}
+	// below threshold
+	extra = NewMap()
+	small := []byte("Hello world!")
+	if out = pc.Compress(small, extra); !bytes.Equal(out, small) || len(extra) != 0 {
+		t.Errorf("small payload compressed: %v", extra)
+	}
+	if out, err = pc.Decompress(small, extra); err != nil || !bytes.Equal(out, small) {
+		t.Errorf("uncompressed payload changed: %v", err)
+	}
+	// incompressible
+	random := make([]byte, 4096)
+	_, _ = rand.Read(random)
+	if out = pc.Compress(random, extra); !bytes.Equal(out, random) || len(extra) != 0 {
+		t.Errorf("random payload compressed: %v", extra)
+	}
+	// unknown codec
+	if _, err = pc.Decompress(compressed, StringKeyMap{"compression": ZSTD}); err != ErrCodecNotSupported {
+		t.Errorf("unknown codec: %v", err)
+	}
+}
+
+func TestPayloadCompressorZeroValue(t *testing.T) {
+	data := textPayload()
+	pc := &PayloadCompressor{Algorithm: DEFLATE}
+	extra := NewMap()
+	compressed := pc.Compress(data, extra)
+	out, err := pc.Decompress(compressed, extra)
+	if err != nil || !bytes.Equal(out, data) {
+		t.Errorf("zero MaxSize rejected: %v", err)
+	}
+}
+
+func TestCompressionBomb(t *testing.T) {
+	// 16 MiB of zeros shrink to a few KiB
+	bomb := make([]byte, 16<<20)
+	extra := NewMap()
+	compressed := (&PayloadCompressor{Algorithm: DEFLATE}).Compress(bomb, extra)
+	if len(compressed) > 64<<10 {
+		t.Fatalf("compressed to %d bytes", len(compressed))
+	}
+	// default limit
+	pc := &PayloadCompressor{}
+	if out, err := pc.Decompress(compressed, extra); err != ErrSizeLimit || out != nil {
+		t.Errorf("default limit not checked: %d bytes, %v", len(out), err)
+	}
+	// custom limit
+	pc.MaxSize = 1 << 20
+	if _, err := pc.Decompress(compressed, extra); err != ErrSizeLimit {
+		t.Errorf("limit not checked: %v", err)
+	}
+	pc.MaxSize = len(bomb)
+	if out, err := pc.Decompress(compressed, extra); err != nil || len(out) != len(bomb) {
+		t.Errorf("exact limit rejected: %v", err)
+	}
+}
+
+func TestCompressedKey(t *testing.T) {
+	data := textPayload()
+	aes := GenerateSymmetricKey(AES)
+	key := NewCompressedKey(aes, NewPayloadCompressor(DEFLATE))
+	extra := NewMap()
+	ciphertext := key.Encrypt(data, extra)
+	if ciphertext == nil || extra["compression"] != DEFLATE || len(ciphertext) >= len(data) {
+		t.Fatalf("not compressed: %v", extra)
+	}
+	if out := key.Decrypt(ciphertext, extra); !bytes.Equal(out, data) {
+		t.Errorf("round trip failed")
+	}
+	// the inner key only gets the compressed payload
+	if out := aes.Decrypt(ciphertext, extra); out == nil || bytes.Equal(out, data) {
+		t.Errorf("payload not compressed")
+	}
+	// same key info
+	if !key.Equal(aes) || key.GetString("data", "") != aes.GetString("data", "") {
+		t.Errorf("key info changed")
+	}
+	// peers without compression
+	params := NewMap()
+	plain := aes.Encrypt(data, params)
+	if out := key.Decrypt(plain, params); !bytes.Equal(out, data) {
+		t.Errorf("uncompressed message rejected")
+	}
+}
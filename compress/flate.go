@@ -0,0 +1,123 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package compress
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"io"
+)
+
+func NewDeflateCodec() Codec {
+	return &DeflateCodec{}
+}
+
+// DeflateCodec implements raw DEFLATE (RFC 1951)
+type DeflateCodec struct {
+	//Codec
+}
+
+// Override
+func (DeflateCodec) Algorithm() string {
+	return DEFLATE
+}
+
+// Override
+func (DeflateCodec) Compress(data []byte) []byte {
+	buf := &bytes.Buffer{}
+	writer, err := flate.NewWriter(buf, flate.BestCompression)
+	if err != nil {
+		return nil
+	}
+	return finish(buf, writer, data)
+}
+
+// Override
+func (DeflateCodec) Decompress(data []byte, limit int) ([]byte, error) {
+	reader := flate.NewReader(bytes.NewReader(data))
+	defer reader.Close()
+	return readAll(reader, limit)
+}
+
+func NewZlibCodec() Codec {
+	return &ZlibCodec{}
+}
+
+// ZlibCodec implements DEFLATE with zlib header and Adler-32 checksum (RFC 1950)
+type ZlibCodec struct {
+	//Codec
+}
+
+// Override
+func (ZlibCodec) Algorithm() string {
+	return ZLIB
+}
+
+// Override
+func (ZlibCodec) Compress(data []byte) []byte {
+	buf := &bytes.Buffer{}
+	writer, err := zlib.NewWriterLevel(buf, zlib.BestCompression)
+	if err != nil {
+		return nil
+	}
+	return finish(buf, writer, data)
+}
+
+// Override
+func (ZlibCodec) Decompress(data []byte, limit int) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return readAll(reader, limit)
+}
+
+func finish(buf *bytes.Buffer, writer io.WriteCloser, data []byte) []byte {
+	if _, err := writer.Write(data); err != nil {
+		return nil
+	} else if err = writer.Close(); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// readAll reads at most 'limit' bytes, one more byte means too large
+func readAll(reader io.Reader, limit int) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	} else if len(data) > limit {
+		return nil, ErrSizeLimit
+	}
+	return data, nil
+}
@@ -0,0 +1,75 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package compress
+
+import (
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/types"
+)
+
+// NewCompressedKey wraps the message key, so the serialized content
+// is compressed before encryption and restored after decryption
+func NewCompressedKey(key SymmetricKey, compressor *PayloadCompressor) SymmetricKey {
+	return &CompressedKey{
+		SymmetricKey: key,
+		compressor:   compressor,
+	}
+}
+
+// CompressedKey applies the payload compressor around 'Encrypt()' & 'Decrypt()'
+//
+// The 'compression' field goes into the extra params with 'IV',
+// the key info itself is unchanged, so it is serialized as the inner key
+type CompressedKey struct {
+	SymmetricKey
+
+	compressor *PayloadCompressor
+}
+
+// Override
+func (key *CompressedKey) Encrypt(plaintext []byte, extra StringKeyMap) []byte {
+	data := key.compressor.Compress(plaintext, extra)
+	return key.SymmetricKey.Encrypt(data, extra)
+}
+
+// Override
+func (key *CompressedKey) Decrypt(ciphertext []byte, params StringKeyMap) []byte {
+	data := key.SymmetricKey.Decrypt(ciphertext, params)
+	if data == nil {
+		return nil
+	}
+	plaintext, err := key.compressor.Decompress(data, params)
+	if err != nil {
+		//panic(err)
+		return nil
+	}
+	return plaintext
+}
@@ -0,0 +1,123 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package compress
+
+import (
+	. "github.com/dimchat/mkm-go/types"
+)
+
+const (
+	// DefaultThreshold is the minimal payload size worth compressing
+	DefaultThreshold = 1024
+
+	// DefaultMaxSize is the maximal size of decompressed payload (8 MiB)
+	DefaultMaxSize = 8 << 20
+)
+
+// NewPayloadCompressor creates compressor with the codec name,
+// default threshold and decompressed-size cap
+func NewPayloadCompressor(algorithm string) *PayloadCompressor {
+	return &PayloadCompressor{
+		Algorithm: algorithm,
+		Threshold: DefaultThreshold,
+		MaxSize:   DefaultMaxSize,
+	}
+}
+
+// PayloadCompressor compresses serialized content before encryption
+//
+// The codec name is put into the extra params of 'SymmetricKey.Encrypt()',
+// which will be stored in the secure message, so the receiver knows how
+// to restore the payload after decryption; old peers which don't know
+// this field will fail to decode the content, instead of accepting garbage;
+// wrap the message key with NewCompressedKey() to apply it automatically
+//
+//	Secure Message JSON Format: {
+//	    "sender"      : "...",
+//	    "receiver"    : "...",
+//	    "time"        : 123,
+//	    "data"        : "...",      // base64_encode(encrypt(compress(content)))
+//	    "compression" : "deflate",  // Optional
+//	    ...
+//	}
+type PayloadCompressor struct {
+
+	// Algorithm is the codec name for compressing (DEFLATE, ZLIB, ZSTD, ...)
+	Algorithm string
+
+	// Threshold is the minimal size to compress, smaller payloads are kept
+	Threshold int
+
+	// MaxSize is the limit of decompressed size, to prevent zip bombs;
+	// zero means DefaultMaxSize
+	MaxSize int
+}
+
+func (pc *PayloadCompressor) maxSize() int {
+	if pc.MaxSize > 0 {
+		return pc.MaxSize
+	}
+	return DefaultMaxSize
+}
+
+// Compress returns the compressed payload, and sets 'compression' in extra params;
+// the payload is kept when it's too small, or it doesn't get smaller
+func (pc *PayloadCompressor) Compress(plaintext []byte, extra StringKeyMap) []byte {
+	if extra == nil || len(plaintext) < pc.Threshold {
+		return plaintext
+	}
+	codec := GetCodec(pc.Algorithm)
+	if codec == nil {
+		//panic("compression codec not supported: " + pc.Algorithm)
+		return plaintext
+	}
+	data := codec.Compress(plaintext)
+	if data == nil || len(data) >= len(plaintext) {
+		return plaintext
+	}
+	extra["compression"] = codec.Algorithm()
+	return data
+}
+
+// Decompress restores the decrypted payload with the 'compression' in params,
+// returns the payload itself when it's not compressed
+func (pc *PayloadCompressor) Decompress(data []byte, params StringKeyMap) ([]byte, error) {
+	algorithm := ConvertString(params["compression"], "")
+	if algorithm == "" {
+		// not compressed
+		return data, nil
+	}
+	codec := GetCodec(algorithm)
+	if codec == nil {
+		return nil, ErrCodecNotSupported
+	}
+	return codec.Decompress(data, pc.maxSize())
+}
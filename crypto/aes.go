@@ -28,6 +28,7 @@ package crypto
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/rand"
 
 	. "github.com/dimchat/core-go/format"
 	. "github.com/dimchat/core-go/protocol"
@@ -40,7 +41,16 @@ import (
 // generate key
 func NewAESKey() SymmetricKey {
 	// random key
-	pwd := RandomBytes(256 / 8) // 32
+	pwd := secureRandom(256 / 8) // 32
+	if pwd == nil {
+		//panic("failed to generate AES key")
+		return nil
+	}
+	return NewAESKeyWithBytes(pwd)
+}
+
+// NewAESKeyWithBytes creates an AES key with the given key material (16/24/32 bytes)
+func NewAESKeyWithBytes(pwd []byte) SymmetricKey {
 	ted := NewBase64DataWithBytes(pwd)
 	// build key info
 	info := NewMap()
@@ -81,6 +91,9 @@ type AESKey struct {
 
 	// data contains the raw AES key material in transportable (serializable) format
 	data TransportableData
+
+	// destroyed is set after the key material was erased by Destroy()
+	destroyed bool
 }
 
 // protected
@@ -110,6 +123,9 @@ func (key *AESKey) Algorithm() string {
 
 // Override
 func (key *AESKey) Data() TransportableData {
+	if key.destroyed {
+		return nil
+	}
 	ted := key.data
 	if ted == nil {
 		base64 := key.Get("data")
@@ -155,7 +171,11 @@ func (key *AESKey) zeroInitVector() []byte {
 func (key *AESKey) newInitVector(extra StringKeyMap) []byte {
 	// random IV data
 	blockSize := key.blockSize()
-	iv := RandomBytes(blockSize)
+	iv := secureRandom(blockSize)
+	if iv == nil {
+		//panic("failed to generate IV")
+		return nil
+	}
 	// pub encoded IV into extra
 	if extra != nil {
 		ted := NewBase64DataWithBytes(iv)
@@ -169,46 +189,121 @@ func (key *AESKey) newInitVector(extra StringKeyMap) []byte {
 
 // Override
 func (key *AESKey) Encrypt(plaintext []byte, extra StringKeyMap) []byte {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
 	// 1. if 'IV' not found in extra params, new a random 'IV'
 	iv := key.initVector(extra)
 	if iv == nil {
 		iv = key.newInitVector(extra)
 	}
 	// 2. get key data
-	data := key.Data()
-	block, err := aes.NewCipher(data.Bytes())
-	if err != nil {
-		panic(err)
+	pwd := keyBytes(key)
+	if pwd == nil {
+		//panic("key data not found")
+		return nil
 	}
 	// 3. try to encrypt
-	blockMode := cipher.NewCBCEncrypter(block, iv)
-	padded := PKCS5Padding(plaintext, key.blockSize())
-	ciphertext := make([]byte, len(padded))
-	blockMode.CryptBlocks(ciphertext, padded)
-	return ciphertext
+	return aesCBCEncrypt(pwd, iv, plaintext)
 }
 
 // Override
 func (key *AESKey) Decrypt(ciphertext []byte, params StringKeyMap) []byte {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
 	// 1. if 'IV' not found in extra params, use an empty 'IV'
 	iv := key.initVector(params)
 	if iv == nil {
 		iv = key.zeroInitVector()
 	}
 	// 2. get key data
-	data := key.Data()
-	block, err := aes.NewCipher(data.Bytes())
-	if err != nil {
-		panic(err)
+	pwd := keyBytes(key)
+	if pwd == nil {
+		//panic("key data not found")
+		return nil
 	}
 	// 3. try to decrypt
-	blockMode := cipher.NewCBCDecrypter(block, iv)
-	plaintext := make([]byte, len(ciphertext))
-	blockMode.CryptBlocks(plaintext, ciphertext)
-	return PKCS5UnPadding(plaintext)
+	return aesCBCDecrypt(pwd, iv, ciphertext)
 }
 
 // Override
 func (key *AESKey) MatchEncryptKey(pKey EncryptKey) bool {
 	return MatchEncryptKey(pKey, key)
 }
+
+//-------- IDestroyableKey
+
+// Override
+func (key *AESKey) Destroy() {
+	wipeData(key.data)
+	key.data = nil
+	key.Remove("data")
+	key.destroyed = true
+}
+
+// Override
+func (key *AESKey) IsDestroyed() bool {
+	return key.destroyed
+}
+
+// secureRandom reads random bytes for key material & IV from crypto/rand
+//
+// Returns: nil on error
+func secureRandom(size uint) []byte {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		return nil
+	}
+	return data
+}
+
+//
+//  AES/CBC/PKCS7Padding
+//
+
+func aesCBCEncrypt(pwd, iv, plaintext []byte) []byte {
+	if len(iv) != aes.BlockSize {
+		//panic("IV length error")
+		return nil
+	}
+	block, err := aes.NewCipher(pwd)
+	if err != nil {
+		//panic(err)
+		return nil
+	}
+	blockMode := cipher.NewCBCEncrypter(block, iv)
+	padded := PKCS5Padding(plaintext, uint(block.BlockSize()))
+	ciphertext := make([]byte, len(padded))
+	blockMode.CryptBlocks(ciphertext, padded)
+	return ciphertext
+}
+
+func aesCBCDecrypt(pwd, iv, ciphertext []byte) []byte {
+	if len(iv) != aes.BlockSize {
+		//panic("IV length error")
+		return nil
+	} else if size := len(ciphertext); size == 0 || size%aes.BlockSize != 0 {
+		//panic("ciphertext length error")
+		return nil
+	}
+	block, err := aes.NewCipher(pwd)
+	if err != nil {
+		//panic(err)
+		return nil
+	}
+	blockMode := cipher.NewCBCDecrypter(block, iv)
+	plaintext := make([]byte, len(ciphertext))
+	blockMode.CryptBlocks(plaintext, ciphertext)
+	// check padding
+	size := len(plaintext)
+	if size == 0 {
+		return nil
+	} else if count := int(plaintext[size-1]); count == 0 || count > block.BlockSize() || count > size {
+		//panic("PKCS7 padding error")
+		return nil
+	}
+	return PKCS5UnPadding(plaintext)
+}
@@ -0,0 +1,211 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/subtle"
+
+	. "github.com/dimchat/core-go/format"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/kdf"
+	. "github.com/dimchat/plugins-go/types"
+)
+
+//goland:noinspection GoSnakeCaseUsage
+const AES_CBC_HMAC_SHA256 = "AES/CBC/PKCS7Padding+HMAC-SHA256"
+
+// HMAC-SHA256 tag size in bytes
+const aesHMACTagSize = 32
+
+// generate key
+func NewAESHMACKey() SymmetricKey {
+	// random key
+	pwd := secureRandom(256 / 8) // 32
+	if pwd == nil {
+		//panic("failed to generate AES key")
+		return nil
+	}
+	ted := NewBase64DataWithBytes(pwd)
+	// build key info
+	info := NewMap()
+	info["algorithm"] = AES_CBC_HMAC_SHA256
+	info["data"] = ted.Serialize()
+	return &AESHMACKey{
+		AESKey: &AESKey{
+			Dictionary: NewDictionary(info),
+			data:       ted,
+		},
+	}
+}
+
+func NewAESHMACKeyWithMap(dict StringKeyMap) SymmetricKey {
+	return &AESHMACKey{
+		AESKey: &AESKey{
+			Dictionary: NewDictionary(dict),
+			// lazy load
+			data: nil,
+		},
+	}
+}
+
+// AESHMACKey is the Encrypt-then-MAC variant of AESKey
+//
+// Separate subkeys for encryption & MAC are derived from the key data,
+// the MAC tag is appended to the ciphertext and verified before decryption:
+//
+//	encKey | macKey = HKDF-SHA256(data, info = algorithm, 32 + 32)
+//	ciphertext      = AES-CBC(encKey, IV, plaintext) | HMAC-SHA256(macKey, IV | AES-CBC(...))
+//
+// The algorithm name differs from "AES", so peers only knowing the plain CBC
+// form cannot parse this key, instead of decrypting garbage
+//
+//	KeyInfo JSON Format: {
+//	    "algorithm" : "AES/CBC/PKCS7Padding+HMAC-SHA256",
+//	    "data"      : "{BASE64}"  // Base64-encoded raw key material (32 bytes)
+//	}
+type AESHMACKey struct {
+	//SymmetricKey
+	*AESKey
+
+	// subkeys derived from the key data
+	encKey []byte
+	macKey []byte
+}
+
+// Override
+func (key *AESHMACKey) Equal(other any) bool {
+	return symmetricKeyEqual(key, other)
+}
+
+// subKeys derives the encryption & MAC keys
+//
+// Returns: nil if the key data not found
+func (key *AESHMACKey) subKeys() (encKey, macKey []byte) {
+	if key.encKey == nil || key.macKey == nil {
+		pwd := keyBytes(key)
+		if pwd == nil {
+			return nil, nil
+		}
+		okm := HKDFDerive("SHA-256", pwd, nil, []byte(AES_CBC_HMAC_SHA256), 32+aesHMACTagSize)
+		if okm == nil {
+			panic("digest algorithm not supported: SHA-256")
+		}
+		key.encKey = okm[:32]
+		key.macKey = okm[32:]
+	}
+	return key.encKey, key.macKey
+}
+
+func aesHMACTag(macKey, iv, ciphertext []byte) []byte {
+	input := make([]byte, 0, len(iv)+len(ciphertext))
+	input = append(input, iv...)
+	input = append(input, ciphertext...)
+	return HMAC("SHA-256", macKey, input)
+}
+
+//-------- ISymmetricKey
+
+// Override
+func (key *AESHMACKey) Encrypt(plaintext []byte, extra StringKeyMap) []byte {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
+	// 1. if 'IV' not found in extra params, new a random 'IV'
+	iv := key.initVector(extra)
+	if iv == nil {
+		iv = key.newInitVector(extra)
+	}
+	// 2. get subkeys
+	encKey, macKey := key.subKeys()
+	if encKey == nil {
+		//panic("key data not found")
+		return nil
+	}
+	// 3. encrypt, then append MAC tag
+	ciphertext := aesCBCEncrypt(encKey, iv, plaintext)
+	if ciphertext == nil {
+		//panic("AES encrypt error")
+		return nil
+	}
+	tag := aesHMACTag(macKey, iv, ciphertext)
+	return append(ciphertext, tag...)
+}
+
+// Override
+func (key *AESHMACKey) Decrypt(ciphertext []byte, params StringKeyMap) []byte {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
+	// 0. check length: at least one block and the tag
+	size := len(ciphertext) - aesHMACTagSize
+	if size < aes.BlockSize || size%aes.BlockSize != 0 {
+		//panic("ciphertext length error")
+		return nil
+	}
+	// 1. if 'IV' not found in extra params, use an empty 'IV'
+	iv := key.initVector(params)
+	if iv == nil {
+		iv = key.zeroInitVector()
+	} else if len(iv) != aes.BlockSize {
+		//panic("IV length error")
+		return nil
+	}
+	// 2. get subkeys
+	encKey, macKey := key.subKeys()
+	if encKey == nil {
+		//panic("key data not found")
+		return nil
+	}
+	// 3. verify MAC tag before decryption
+	body, tag := ciphertext[:size], ciphertext[size:]
+	expected := aesHMACTag(macKey, iv, body)
+	if expected == nil || subtle.ConstantTimeCompare(expected, tag) != 1 {
+		//panic("MAC tag not matched")
+		return nil
+	}
+	// 4. decrypt
+	return aesCBCDecrypt(encKey, iv, body)
+}
+
+// Override
+func (key *AESHMACKey) MatchEncryptKey(pKey EncryptKey) bool {
+	return MatchEncryptKey(pKey, key)
+}
+
+//-------- IDestroyableKey
+
+// Override
+func (key *AESHMACKey) Destroy() {
+	BytesWipe(key.encKey)
+	BytesWipe(key.macKey)
+	key.encKey = nil
+	key.macKey = nil
+	key.AESKey.Destroy()
+}
@@ -0,0 +1,102 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package crypto_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/crypto"
+)
+
+func TestAESHMAC(t *testing.T) {
+	key := GenerateSymmetricKey(AES_CBC_HMAC_SHA256)
+	plaintext := []byte("attack at dawn")
+	params := NewMap()
+	ciphertext := key.Encrypt(plaintext, params)
+	if len(ciphertext) != 16+32 {
+		t.Fatalf("ciphertext length = %d", len(ciphertext))
+	}
+	if out := key.Decrypt(ciphertext, params); !bytes.Equal(out, plaintext) {
+		t.Fatalf("Decrypt() = %q", out)
+	}
+	// flip one byte of the ciphertext body, or of the MAC tag
+	for _, pos := range []int{0, 15, 16, len(ciphertext) - 1} {
+		tampered := append([]byte{}, ciphertext...)
+		tampered[pos] ^= 0x01
+		if out := key.Decrypt(tampered, params); out != nil {
+			t.Errorf("byte %d flipped: Decrypt() = %q", pos, out)
+		}
+	}
+	// truncated tag
+	if key.Decrypt(ciphertext[:len(ciphertext)-1], params) != nil {
+		t.Errorf("truncated: Decrypt() not nil")
+	}
+	// another IV
+	if key.Decrypt(ciphertext, NewMap()) != nil {
+		t.Errorf("zero IV: Decrypt() not nil")
+	}
+	// another key
+	if GenerateSymmetricKey(AES_CBC_HMAC_SHA256).Decrypt(ciphertext, params) != nil {
+		t.Errorf("another key: Decrypt() not nil")
+	}
+}
+
+func TestAESRandom(t *testing.T) {
+	for _, algorithm := range []string{AES, AES_CBC_HMAC_SHA256} {
+		k1 := GenerateSymmetricKey(algorithm)
+		k2 := GenerateSymmetricKey(algorithm)
+		if bytes.Equal(k1.Data().Bytes(), k2.Data().Bytes()) {
+			t.Errorf("%s: same key data generated", algorithm)
+		}
+		p1, p2 := NewMap(), NewMap()
+		k1.Encrypt([]byte("hello"), p1)
+		k1.Encrypt([]byte("hello"), p2)
+		if p1["IV"] == nil || p1["IV"] == p2["IV"] {
+			t.Errorf("%s: same IV generated: %v", algorithm, p1["IV"])
+		}
+	}
+}
+
+func TestAESInvalid(t *testing.T) {
+	key := GenerateSymmetricKey(AES)
+	params := NewMap()
+	ciphertext := key.Encrypt([]byte("hello"), params)
+	// wrong length of IV or ciphertext
+	badIV := NewMap()
+	badIV["IV"] = "AAAA"
+	if key.Encrypt([]byte("hello"), badIV) != nil || key.Decrypt(ciphertext, badIV) != nil {
+		t.Errorf("bad IV accepted")
+	}
+	for _, data := range [][]byte{nil, ciphertext[:15], append(ciphertext, 0)} {
+		if key.Decrypt(data, params) != nil {
+			t.Errorf("ciphertext with length %d accepted", len(data))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package crypto
+
+import (
+	"strings"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+)
+
+// AgreementKey is an asymmetric private key that can agree on a shared secret
+// with the owner of another public key, without sending any encrypted key blob
+//
+//	shared = ECDH(SK, PK)
+//	key    = HKDF-SHA256(shared, info)
+//
+// Only ECC keys (secp256k1, P-256, P-384) are supported. X25519 is not: this module
+// has no Ed25519/X25519 key type, and crypto/ecdh needs Go 1.20 while this module
+// requires Go 1.18, so KeyAgreement() returns nil for such peers.
+type AgreementKey interface {
+	PrivateKey
+
+	// KeyAgreement derives the symmetric key shared with the peer
+	//
+	// Both sides get the same key when using the same info, so different
+	// conversations should pass different context info to get different keys
+	//
+	// Parameters:
+	//   - peer - public key of the other side (must use the same curve)
+	//   - info - context info for key derivation, e.g.: conversation ID
+	// Returns: AES key, nil if the peer key is not compatible
+	KeyAgreement(peer PublicKey, info []byte) SymmetricKey
+}
+
+// isECCAlgorithm checks for "ECC" and the ECDSA aliases, e.g.: "SHA256withECDSA"
+func isECCAlgorithm(algorithm string) bool {
+	return algorithm == ECC || strings.HasSuffix(algorithm, "withECDSA")
+}
+
+// eccPublicKeyBytes returns the raw public key (X + Y) on the curve
+//
+// Returns: nil if the key is not an ECC key on the same curve
+func eccPublicKeyBytes(key PublicKey, curve ECCCurve) []byte {
+	if key == nil || curve == nil || !isECCAlgorithm(key.Algorithm()) {
+		return nil
+	} else if GetKeyCurve(key.Map()) != curve {
+		return nil
+	}
+	ted := key.Data()
+	if ted == nil {
+		return nil
+	}
+	pub := ted.Bytes()
+	size := curve.KeySize()
+	switch len(pub) {
+	case size * 2:
+		return pub
+	case size*2 + 1:
+		if pub[0] == 0x04 {
+			return pub[1:]
+		}
+	case size + 1:
+		return curve.Decompress(pub)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package crypto_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/plugins-go/crypto"
+)
+
+func TestKeyAgreement(t *testing.T) {
+	info := []byte("conversation-1")
+	for _, name := range []string{CURVE_SECP256K1, CURVE_P256, CURVE_P384} {
+		alice := NewECCPrivateKeyWithCurve(name).(AgreementKey)
+		bob := NewECCPrivateKeyWithCurve(name).(AgreementKey)
+		// reload the peer's public key from its dictionary
+		bobPub := ParsePublicKey(bob.PublicKey().Map())
+		alicePub := ParsePublicKey(alice.PublicKey().Map())
+		k1 := alice.KeyAgreement(bobPub, info)
+		k2 := bob.KeyAgreement(alicePub, info)
+		if k1 == nil || k2 == nil {
+			t.Fatalf("%s: key agreement failed", name)
+		}
+		if !bytes.Equal(k1.Data().Bytes(), k2.Data().Bytes()) {
+			t.Errorf("%s: both sides derived different keys", name)
+		}
+		if k1.Algorithm() != AES || !MatchEncryptKey(k1, k2) {
+			t.Errorf("%s: derived key not a matching AES key", name)
+		}
+		// different context info, different key
+		k3 := alice.KeyAgreement(bobPub, []byte("conversation-2"))
+		if bytes.Equal(k1.Data().Bytes(), k3.Data().Bytes()) {
+			t.Errorf("%s: same key for different info", name)
+		}
+	}
+}
+
+func TestKeyAgreementIncompatible(t *testing.T) {
+	alice := NewECCPrivateKeyWithCurve(CURVE_SECP256K1).(AgreementKey)
+	others := map[string]PublicKey{
+		"P-256": NewECCPrivateKeyWithCurve(CURVE_P256).PublicKey(),
+		"RSA":   GeneratePrivateKey(RSA).PublicKey(),
+	}
+	for name, peer := range others {
+		if alice.KeyAgreement(peer, nil) != nil {
+			t.Errorf("agreed with %s peer key", name)
+		}
+	}
+	if alice.KeyAgreement(nil, nil) != nil {
+		t.Errorf("agreed with nil peer key")
+	}
+}
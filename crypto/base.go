@@ -31,11 +31,17 @@
 package crypto
 
 import (
+	"crypto"
+	"errors"
 	"reflect"
+	"strings"
 
 	. "github.com/dimchat/mkm-go/crypto"
 	. "github.com/dimchat/mkm-go/ext"
+	. "github.com/dimchat/mkm-go/format"
 	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/digest"
+	. "github.com/dimchat/plugins-go/types"
 )
 
 //
@@ -55,6 +61,128 @@ func MatchSignKey(sKey SignKey, pKey VerifyKey) bool {
 	return MatchAsymmetricKeys(sKey, pKey)
 }
 
+//
+//  Signature Digest
+//
+
+// GetDigestAlgorithm returns the (normalized) digest algorithm for signing with the key
+//
+//  1. "digest" field:            "SHA256", "SHA-512", "KECCAK256", ...
+//  2. prefix of the algorithm:   "SHA512withRSA", "KECCAK256withECDSA", ...
+//  3. default:                   "SHA256"
+func GetDigestAlgorithm(key StringKeyMap) string {
+	name := ConvertString(key["digest"], "")
+	if name == "" {
+		algorithm := GetKeyAlgorithm(key)
+		pos := strings.Index(algorithm, "with")
+		if pos > 0 {
+			name = algorithm[:pos]
+		} else {
+			name = "SHA256"
+		}
+	}
+	return DigestAlgorithm(name)
+}
+
+// digestData computes the digest of data with the hash function chosen by the key
+//
+// Returns: nil if the digest algorithm is not registered
+func digestData(key StringKeyMap, data []byte) []byte {
+	name := GetDigestAlgorithm(key)
+	digester := GetDigester(name)
+	if digester == nil {
+		//panic("digest algorithm not supported: " + name)
+		return nil
+	}
+	return digester.Digest(data)
+}
+
+// signatureHash returns the hash identifier used to build the PKCS#1 DigestInfo,
+// zero means the digest cannot be used for RSA signature:
+//
+//  1. Keccak-256 has no standard OID;
+//  2. crypto/rsa supports SHA3 only since Go 1.24 (this module requires Go 1.18).
+func signatureHash(key StringKeyMap) crypto.Hash {
+	name := GetDigestAlgorithm(key)
+	switch name {
+	case "MD5":
+		return crypto.MD5
+	case "SHA1":
+		return crypto.SHA1
+	case "SHA224":
+		return crypto.SHA224
+	case "SHA256":
+		return crypto.SHA256
+	case "SHA384":
+		return crypto.SHA384
+	case "SHA512":
+		return crypto.SHA512
+	default:
+		return 0
+	}
+}
+
+//
+//  Destruction
+//
+
+// ErrKeyDestroyed is the error reported by CheckKey() for a key erased by Destroy()
+var ErrKeyDestroyed = errors.New("crypto key destroyed")
+
+// DestroyableKey is a key whose secret material can be erased from memory explicitly
+//
+// After Destroy() the key data is zeroed, the "data" field is removed from the key info
+// and any cached public key is dropped; every later operation that needs the secret
+// fails like the other error paths (Data, PublicKey, Sign, Encrypt, Decrypt & KeyToJWK
+// return nil), callers can call CheckKey() to tell why.
+//
+// Note: encoded strings (Base64/Hex/PEM) are immutable in Go, so the copies kept
+// inside the key info cannot be overwritten, they are only released to the GC.
+type DestroyableKey interface {
+
+	// Destroy erases the secret key material
+	Destroy()
+
+	// IsDestroyed returns true after Destroy() was called
+	IsDestroyed() bool
+}
+
+// DestroyKey erases the key material if the key supports it,
+// key holders (keystores, caches, ...) should call it when they evict keys
+//
+// Returns: false if the key is not destroyable
+func DestroyKey(key any) bool {
+	if dk, ok := key.(DestroyableKey); ok {
+		dk.Destroy()
+		return true
+	}
+	return false
+}
+
+// CheckKey returns ErrKeyDestroyed if the key material was erased by Destroy()
+func CheckKey(key any) error {
+	if dk, ok := key.(DestroyableKey); ok && dk.IsDestroyed() {
+		return ErrKeyDestroyed
+	}
+	return nil
+}
+
+// keyBytes returns the raw key data, nil if not found
+func keyBytes(key CryptographyKey) []byte {
+	ted := key.Data()
+	if ted == nil {
+		return nil
+	}
+	return ted.Bytes()
+}
+
+// wipeData zeroes the decoded bytes held by a transportable data
+func wipeData(ted TransportableData) {
+	if ted != nil && !ted.IsEmpty() {
+		BytesWipe(ted.Bytes())
+	}
+}
+
 //func SymmetricKeysEqual(a, b SymmetricKey) bool {
 //	if a == nil || b == nil {
 //		return a == b
@@ -119,7 +247,12 @@ func privateKeyEqual(key PrivateKey, other any) bool {
 	switch v := other.(type) {
 	case PrivateKey:
 		// compare by signature
-		return MatchSignKey(v, key.PublicKey())
+		pKey := key.PublicKey()
+		if pKey == nil {
+			// key destroyed
+			return false
+		}
+		return MatchSignKey(v, pKey)
 	case Mapper:
 		dictionary = v.Map()
 	case StringKeyMap:
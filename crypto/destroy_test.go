@@ -0,0 +1,134 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package crypto_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/crypto"
+	"github.com/dimchat/plugins-go/ext"
+)
+
+func init() {
+	ext.ExtensionLoader{}.Load()
+	ext.PluginLoader{}.Load()
+}
+
+// checkDestroyed checks that the key reports ErrKeyDestroyed and exports nothing
+func checkDestroyed(t *testing.T, name string, key CryptographyKey) {
+	if !key.(DestroyableKey).IsDestroyed() {
+		t.Errorf("%s: IsDestroyed() = false", name)
+	}
+	if err := CheckKey(key); !errors.Is(err, ErrKeyDestroyed) {
+		t.Errorf("%s: CheckKey() = %v, want ErrKeyDestroyed", name, err)
+	}
+	if key.Data() != nil {
+		t.Errorf("%s: Data() not nil", name)
+	}
+	if _, ok := key.Map()["data"]; ok {
+		t.Errorf("%s: key info still has data", name)
+	}
+	if KeyToJWK(key) != nil {
+		t.Errorf("%s: KeyToJWK() not nil", name)
+	}
+}
+
+func TestDestroySymmetricKey(t *testing.T) {
+	for _, algorithm := range []string{AES, AES_CBC_HMAC_SHA256} {
+		key := GenerateSymmetricKey(algorithm)
+		params := NewMap()
+		ciphertext := key.Encrypt([]byte("hello"), params)
+		if ciphertext == nil {
+			t.Fatalf("%s: failed to encrypt", algorithm)
+		}
+		if !DestroyKey(key) {
+			t.Fatalf("%s: key not destroyable", algorithm)
+		}
+		checkDestroyed(t, algorithm, key)
+		if key.Encrypt([]byte("hello"), NewMap()) != nil {
+			t.Errorf("%s: Encrypt() not nil", algorithm)
+		}
+		if key.Decrypt(ciphertext, params) != nil {
+			t.Errorf("%s: Decrypt() not nil", algorithm)
+		}
+		if key.MatchEncryptKey(key) {
+			t.Errorf("%s: MatchEncryptKey() = true", algorithm)
+		}
+		if key.Equal(GenerateSymmetricKey(algorithm)) {
+			t.Errorf("%s: Equal() = true", algorithm)
+		}
+		// destroy twice
+		DestroyKey(key)
+		checkDestroyed(t, algorithm, key)
+	}
+}
+
+func TestDestroyPrivateKey(t *testing.T) {
+	keys := map[string]PrivateKey{
+		"RSA":           GeneratePrivateKey(RSA),
+		CURVE_SECP256K1: NewECCPrivateKeyWithCurve(CURVE_SECP256K1),
+		CURVE_P256:      NewECCPrivateKeyWithCurve(CURVE_P256),
+		CURVE_P384:      NewECCPrivateKeyWithCurve(CURVE_P384),
+	}
+	for name, key := range keys {
+		other := key.PublicKey()
+		if !DestroyKey(key) {
+			t.Fatalf("%s: key not destroyable", name)
+		}
+		checkDestroyed(t, name, key)
+		if key.Sign([]byte("hello")) != nil {
+			t.Errorf("%s: Sign() not nil", name)
+		}
+		if key.PublicKey() != nil {
+			t.Errorf("%s: PublicKey() not nil", name)
+		}
+		if !key.Equal(key) || key.Equal(GeneratePrivateKey(RSA)) {
+			t.Errorf("%s: Equal() error", name)
+		}
+		if dk, ok := key.(DecryptKey); ok {
+			ciphertext := other.(EncryptKey).Encrypt([]byte("hello"), nil)
+			if dk.Decrypt(ciphertext, nil) != nil {
+				t.Errorf("%s: Decrypt() not nil", name)
+			}
+			if dk.MatchEncryptKey(other.(EncryptKey)) {
+				t.Errorf("%s: MatchEncryptKey() = true", name)
+			}
+		}
+		if ak, ok := key.(AgreementKey); ok {
+			if ak.KeyAgreement(other, []byte("info")) != nil {
+				t.Errorf("%s: KeyAgreement() not nil", name)
+			}
+		}
+		// the public key still works
+		if KeyToJWK(other) == nil {
+			t.Errorf("%s: public key not exported", name)
+		}
+	}
+}
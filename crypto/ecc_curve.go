@@ -0,0 +1,295 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"strings"
+
+	. "github.com/dimchat/mkm-go/types"
+	"github.com/dimchat/plugins-go/crypto/secp256k1"
+)
+
+// ECCCurve is the elliptic curve used by ECC keys, selected by the "curve" field
+//
+// All curves share the same key encoding:
+//   - private key: big-endian scalar (KeySize bytes)
+//   - public key:  raw point X + Y (2 * KeySize bytes), "04" prefixed in key info
+//   - signature:   DER encoded (r, s)
+type ECCCurve interface {
+
+	// Name returns the curve name for the "curve" field
+	Name() string
+
+	// KeySize returns the private key size in bytes
+	KeySize() int
+
+	// Generate creates a new key pair
+	Generate() (pub, pri []byte)
+
+	// PublicKey calculates the raw public key (X + Y) from the private key
+	PublicKey(pri []byte) []byte
+
+	// Decompress converts the compressed public key (KeySize + 1 bytes) to raw (X + Y)
+	Decompress(compressed []byte) []byte
+
+	// Sign signs the digest, returns DER encoded signature
+	Sign(pri, digest []byte) []byte
+
+	// Verify checks the DER encoded (or raw r + s) signature of the digest
+	Verify(pub, digest, signature []byte) bool
+
+	// SharedSecret calculates the ECDH shared secret (X coordinate)
+	SharedSecret(pub, pri []byte) []byte
+}
+
+//goland:noinspection GoSnakeCaseUsage
+const (
+	CURVE_SECP256K1 = "SECP256k1"
+	CURVE_P256      = "P-256"
+	CURVE_P384      = "P-384"
+)
+
+// GetECCCurve returns the elliptic curve for the name, e.g.:
+// "secp256k1", "P-256" (aka "secp256r1", "prime256v1"), "P-384" (aka "secp384r1")
+//
+// Returns: nil if the curve is not supported
+func GetECCCurve(name string) ECCCurve {
+	switch strings.ToUpper(name) {
+	case "SECP256K1":
+		return k1Curve
+	case "P-256", "P256", "SECP256R1", "PRIME256V1":
+		return p256Curve
+	case "P-384", "P384", "SECP384R1":
+		return p384Curve
+	}
+	return nil
+}
+
+// GetKeyCurve returns the elliptic curve for the ECC key info,
+// default is secp256k1 if the "curve" field not found
+//
+// Returns: nil if the curve is not supported
+func GetKeyCurve(key StringKeyMap) ECCCurve {
+	name := ConvertString(key["curve"], "")
+	if name == "" {
+		return k1Curve
+	}
+	return GetECCCurve(name)
+}
+
+//
+//  secp256k1 (micro-ecc)
+//
+
+var k1Curve ECCCurve = &secp256k1Curve{}
+
+type secp256k1Curve struct {
+	//ECCCurve
+}
+
+// Override
+func (secp256k1Curve) Name() string {
+	return CURVE_SECP256K1
+}
+
+// Override
+func (secp256k1Curve) KeySize() int {
+	return 32
+}
+
+// Override
+func (secp256k1Curve) Generate() (pub, pri []byte) {
+	return secp256k1.Generate()
+}
+
+// Override
+func (secp256k1Curve) PublicKey(pri []byte) []byte {
+	return secp256k1.GetPublicKey(pri)
+}
+
+// Override
+func (secp256k1Curve) Decompress(compressed []byte) []byte {
+	return secp256k1.Decompress(compressed)
+}
+
+// Override
+func (secp256k1Curve) Sign(pri, digest []byte) []byte {
+	sig := secp256k1.Sign(pri, digest)
+	return secp256k1.SignatureToDER(sig)
+}
+
+// Override
+func (secp256k1Curve) Verify(pub, digest, signature []byte) bool {
+	if len(signature) > 64 {
+		signature = secp256k1.SignatureFromDER(signature)
+	}
+	return secp256k1.Verify(pub, digest, signature)
+}
+
+// Override
+func (secp256k1Curve) SharedSecret(pub, pri []byte) []byte {
+	return secp256k1.SharedSecret(pub, pri)
+}
+
+//
+//  NIST curves (crypto/ecdsa)
+//
+
+var (
+	p256Curve ECCCurve = &nistCurve{name: CURVE_P256, curve: elliptic.P256()}
+	p384Curve ECCCurve = &nistCurve{name: CURVE_P384, curve: elliptic.P384()}
+)
+
+type nistCurve struct {
+	//ECCCurve
+
+	name  string
+	curve elliptic.Curve
+}
+
+// Override
+func (c *nistCurve) Name() string {
+	return c.name
+}
+
+// Override
+func (c *nistCurve) KeySize() int {
+	return (c.curve.Params().BitSize + 7) / 8
+}
+
+// Override
+func (c *nistCurve) Generate() (pub, pri []byte) {
+	key, err := ecdsa.GenerateKey(c.curve, rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	pri = make([]byte, c.KeySize())
+	key.D.FillBytes(pri)
+	return c.encodePoint(key.X, key.Y), pri
+}
+
+// Override
+func (c *nistCurve) PublicKey(pri []byte) []byte {
+	key := c.privateKey(pri)
+	if key == nil {
+		return nil
+	}
+	return c.encodePoint(key.X, key.Y)
+}
+
+// Override
+func (c *nistCurve) Decompress(compressed []byte) []byte {
+	x, y := elliptic.UnmarshalCompressed(c.curve, compressed)
+	if x == nil {
+		return nil
+	}
+	return c.encodePoint(x, y)
+}
+
+// Override
+func (c *nistCurve) Sign(pri, digest []byte) []byte {
+	key := c.privateKey(pri)
+	if key == nil {
+		panic("ECC private key error")
+	}
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+// Override
+func (c *nistCurve) Verify(pub, digest, signature []byte) bool {
+	key := c.publicKey(pub)
+	if key == nil {
+		return false
+	}
+	size := c.KeySize()
+	if len(signature) == size*2 {
+		// raw (r + s)
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(key, digest, r, s)
+	}
+	return ecdsa.VerifyASN1(key, digest, signature)
+}
+
+// Override
+func (c *nistCurve) SharedSecret(pub, pri []byte) []byte {
+	pKey := c.publicKey(pub)
+	sKey := c.privateKey(pri)
+	if pKey == nil || sKey == nil {
+		return nil
+	}
+	x, _ := c.curve.ScalarMult(pKey.X, pKey.Y, pri)
+	secret := make([]byte, c.KeySize())
+	x.FillBytes(secret)
+	return secret
+}
+
+func (c *nistCurve) privateKey(pri []byte) *ecdsa.PrivateKey {
+	if len(pri) != c.KeySize() {
+		return nil
+	}
+	d := new(big.Int).SetBytes(pri)
+	if d.Sign() == 0 || d.Cmp(c.curve.Params().N) >= 0 {
+		return nil
+	}
+	key := &ecdsa.PrivateKey{D: d}
+	key.Curve = c.curve
+	key.X, key.Y = c.curve.ScalarBaseMult(pri)
+	return key
+}
+
+func (c *nistCurve) publicKey(pub []byte) *ecdsa.PublicKey {
+	size := c.KeySize()
+	if len(pub) != size*2 {
+		return nil
+	}
+	x := new(big.Int).SetBytes(pub[:size])
+	y := new(big.Int).SetBytes(pub[size:])
+	if !c.curve.IsOnCurve(x, y) {
+		return nil
+	}
+	return &ecdsa.PublicKey{
+		Curve: c.curve,
+		X:     x,
+		Y:     y,
+	}
+}
+
+func (c *nistCurve) encodePoint(x, y *big.Int) []byte {
+	size := c.KeySize()
+	pub := make([]byte, size*2)
+	x.FillBytes(pub[:size])
+	y.FillBytes(pub[size:])
+	return pub
+}
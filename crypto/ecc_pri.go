@@ -29,23 +29,36 @@ import (
 	. "github.com/dimchat/core-go/format"
 	. "github.com/dimchat/core-go/protocol"
 	. "github.com/dimchat/mkm-go/crypto"
-	. "github.com/dimchat/mkm-go/digest"
 	. "github.com/dimchat/mkm-go/format"
 	. "github.com/dimchat/mkm-go/types"
-	"github.com/dimchat/plugins-go/crypto/secp256k1"
+	. "github.com/dimchat/plugins-go/kdf"
+	. "github.com/dimchat/plugins-go/types"
 )
 
 // generate key
 func NewECCPrivateKey() PrivateKey {
+	return NewECCPrivateKeyWithCurve(CURVE_SECP256K1)
+}
+
+// NewECCPrivateKeyWithCurve generates key on the elliptic curve
+// ("secp256k1", "P-256", "P-384")
+//
+// Returns: nil if the curve is not supported
+func NewECCPrivateKeyWithCurve(name string) PrivateKey {
+	curve := GetECCCurve(name)
+	if curve == nil {
+		//panic("curve not supported: " + name)
+		return nil
+	}
 	// generate key
-	_, pri := secp256k1.Generate()
+	_, pri := curve.Generate()
 	ted := NewPlainDataWithBytes(pri)
 	txt := HexEncode(pri)
 	// build key info
 	info := NewMap()
 	info["algorithm"] = ECC
 	info["data"] = txt
-	info["curve"] = "SECP256k1"
+	info["curve"] = curve.Name()
 	info["digest"] = "SHA256"
 	return &ECCPrivateKey{
 		Dictionary: NewDictionary(info),
@@ -65,11 +78,13 @@ func NewECCPrivateKeyWithMap(dict StringKeyMap) PrivateKey {
 
 // ECCPrivateKey implements the PrivateKey interface for ECC (Elliptic Curve Cryptography)
 //
-// Uses secp256k1 curve (Bitcoin/Ethereum standard) for asymmetric cryptography
+// Uses secp256k1 curve (Bitcoin/Ethereum standard) by default,
+// NIST P-256 & P-384 are selected by the "curve" field
 //
 //	KeyInfo JSON Format: {
 //	    "algorithm" : "ECC",
-//	    "curve"     : "secp256k1",  // Elliptic curve identifier (secp256k1 is primary supported curve)
+//	    "curve"     : "secp256k1",  // Elliptic curve identifier ("secp256k1", "P-256", "P-384")
+//	    "digest"    : "SHA256",     // Optional: Signature digest ("SHA256", "SHA512", "KECCAK256", ...)
 //	    "data"      : "{BASE64}"    // Base64-encoded raw ECC private key material
 //	}
 type ECCPrivateKey struct {
@@ -81,6 +96,9 @@ type ECCPrivateKey struct {
 
 	// publicKey caches the corresponding ECCPublicKey derived from this private key
 	publicKey PublicKey
+
+	// destroyed is set after the key material was erased by Destroy()
+	destroyed bool
 }
 
 // Override
@@ -88,6 +106,11 @@ func (key *ECCPrivateKey) Equal(other any) bool {
 	return privateKeyEqual(key, other)
 }
 
+// protected
+func (key *ECCPrivateKey) curve() ECCCurve {
+	return GetKeyCurve(key.Map())
+}
+
 //-------- ICryptographyKey
 
 // Override
@@ -98,12 +121,15 @@ func (key *ECCPrivateKey) Algorithm() string {
 
 // Override
 func (key *ECCPrivateKey) Data() TransportableData {
+	if key.destroyed {
+		return nil
+	}
 	ted := key.data
 	if ted == nil {
 		text := key.GetString("data", "")
 		size := len(text)
-		if size == 64 {
-			// check for raw data (32 bytes)
+		if curve := key.curve(); curve != nil && size == curve.KeySize()*2 {
+			// check for raw data (32 bytes for secp256k1)
 			// Hex format
 			bin := HexDecode(text)
 			ted = NewPlainDataWithBytes(bin)
@@ -119,25 +145,54 @@ func (key *ECCPrivateKey) Data() TransportableData {
 
 // Override
 func (key *ECCPrivateKey) Sign(data []byte) []byte {
-	ted := key.Data()
-	sig := secp256k1.Sign(ted.Bytes(), SHA256(data))
-	return secp256k1.SignatureToDER(sig)
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
+	pri := keyBytes(key)
+	if pri == nil {
+		//panic("key data not found")
+		return nil
+	}
+	info := key.Map()
+	hash := digestData(info, data)
+	if hash == nil {
+		//panic("digest algorithm not supported: " + GetDigestAlgorithm(info))
+		return nil
+	}
+	curve := key.curve()
+	if curve == nil {
+		//panic("curve not supported: " + key.GetString("curve", ""))
+		return nil
+	}
+	return curve.Sign(pri, hash)
 }
 
 // Override
 func (key *ECCPrivateKey) PublicKey() PublicKey {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
 	publicKey := key.publicKey
 	if publicKey == nil {
-		ted := key.Data()
-		pri := ted.Bytes()
-		pub := secp256k1.GetPublicKey(pri)
+		pri := keyBytes(key)
+		if pri == nil {
+			//panic("key data not found")
+			return nil
+		}
+		curve := key.curve()
+		if curve == nil {
+			panic("curve not supported: " + key.GetString("curve", ""))
+		}
+		pub := curve.PublicKey(pri)
 		txt := "04" + HexEncode(pub)
 		// build key info
 		info := NewMap()
 		info["algorithm"] = ECC
 		info["data"] = txt
-		info["curve"] = "SECP256k1"
-		info["digest"] = "SHA256"
+		info["curve"] = curve.Name()
+		info["digest"] = GetDigestAlgorithm(key.Map())
 		publicKey = &ECCPublicKey{
 			Dictionary: NewDictionary(info),
 			data:       nil, // lazy load
@@ -146,3 +201,52 @@ func (key *ECCPrivateKey) PublicKey() PublicKey {
 	}
 	return publicKey
 }
+
+//-------- IAgreementKey
+
+// Override
+func (key *ECCPrivateKey) KeyAgreement(peer PublicKey, info []byte) SymmetricKey {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
+	curve := key.curve()
+	pub := eccPublicKeyBytes(peer, curve)
+	if pub == nil {
+		//panic("peer public key not compatible")
+		return nil
+	}
+	pri := keyBytes(key)
+	if pri == nil {
+		//panic("key data not found")
+		return nil
+	}
+	secret := curve.SharedSecret(pub, pri)
+	if secret == nil {
+		//panic("failed to agree on shared secret")
+		return nil
+	}
+	pwd := HKDFDerive("SHA-256", secret, nil, info, 256/8) // 32
+	BytesWipe(secret)
+	if pwd == nil {
+		//panic("digest algorithm not supported: SHA-256")
+		return nil
+	}
+	return NewAESKeyWithBytes(pwd)
+}
+
+//-------- IDestroyableKey
+
+// Override
+func (key *ECCPrivateKey) Destroy() {
+	wipeData(key.data)
+	key.data = nil
+	key.publicKey = nil
+	key.Remove("data")
+	key.destroyed = true
+}
+
+// Override
+func (key *ECCPrivateKey) IsDestroyed() bool {
+	return key.destroyed
+}
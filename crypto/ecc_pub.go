@@ -28,10 +28,8 @@ package crypto
 import (
 	. "github.com/dimchat/core-go/format"
 	. "github.com/dimchat/mkm-go/crypto"
-	. "github.com/dimchat/mkm-go/digest"
 	. "github.com/dimchat/mkm-go/format"
 	. "github.com/dimchat/mkm-go/types"
-	"github.com/dimchat/plugins-go/crypto/secp256k1"
 )
 
 func NewECCPublicKeyWithMap(dict StringKeyMap) PublicKey {
@@ -44,11 +42,12 @@ func NewECCPublicKeyWithMap(dict StringKeyMap) PublicKey {
 
 // ECCPublicKey implements the PublicKey interface for ECC (Elliptic Curve Cryptography)
 //
-// Corresponding public key for ECCPrivateKey, uses secp256k1 curve
+// Corresponding public key for ECCPrivateKey, uses secp256k1 curve by default
 //
 //	KeyInfo JSON Format: {
 //	    "algorithm": "ECC",
 //	    "curve": "secp256k1",  // Elliptic curve identifier (matches private key curve)
+//	    "digest": "SHA256",    // Optional: Signature digest (matches private key digest)
 //	    "data": "{BASE64}"     // Base64-encoded raw ECC public key material
 //	}
 type ECCPublicKey struct {
@@ -59,6 +58,11 @@ type ECCPublicKey struct {
 	data TransportableData
 }
 
+// protected
+func (key *ECCPublicKey) curve() ECCCurve {
+	return GetKeyCurve(key.Map())
+}
+
 //-------- ICryptographyKey
 
 // Override
@@ -72,9 +76,11 @@ func (key *ECCPublicKey) Data() TransportableData {
 	ted := key.data
 	if ted == nil {
 		text := key.GetString("data", "")
-		// check for raw data (33/65 bytes)
+		// check for raw data (33/65 bytes for secp256k1)
 		size := len(text)
-		if size == 66 || size == 130 {
+		if curve := key.curve(); curve == nil {
+			// curve not supported
+		} else if n := curve.KeySize() * 2; size == n+2 || size == n*2+2 {
 			// Hex format
 			bin := HexDecode(text)
 			ted = NewPlainDataWithBytes(bin)
@@ -90,15 +96,18 @@ func (key *ECCPublicKey) Data() TransportableData {
 
 // Override
 func (key *ECCPublicKey) Verify(data []byte, signature []byte) bool {
-	if len(signature) > 64 {
-		signature = secp256k1.SignatureFromDER(signature)
+	hash := digestData(key.Map(), data)
+	if hash == nil {
+		//panic("digest algorithm not supported")
+		return false
 	}
-	ted := key.Data()
-	pub := ted.Bytes()
-	if len(pub) == 65 {
-		pub = pub[1:]
+	curve := key.curve()
+	pub := eccPublicKeyBytes(key, curve)
+	if pub == nil {
+		//panic("ECC public key error")
+		return false
 	}
-	return secp256k1.Verify(pub, SHA256(data), signature)
+	return curve.Verify(pub, hash, signature)
 }
 
 // Override
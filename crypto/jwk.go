@@ -0,0 +1,491 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package crypto
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+
+	. "github.com/dimchat/core-go/format"
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/digest"
+	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/types"
+)
+
+/**
+ *  JSON Web Key (RFC 7517)
+ *
+ *  Converts between the key dictionaries of this project and JWK objects:
+ *
+ *      RSA key (PEM "data")    <=>  {"kty": "RSA", "n": ..., "e": ..., "d": ...}
+ *      ECC key (hex "data")    <=>  {"kty": "EC", "crv": "secp256k1", "x": ..., "y": ..., "d": ...}
+ *                                   (crv: "secp256k1", "P-256", "P-384")
+ *      AES key (base64 "data") <=>  {"kty": "oct", "k": ...}
+ *
+ *  The "kid" is the JWK thumbprint (RFC 7638), so a private key and
+ *  its public key always share the same "kid"
+ */
+
+//goland:noinspection GoSnakeCaseUsage
+const (
+	JWK_RSA = "RSA"
+	JWK_EC  = "EC"
+	JWK_OCT = "oct"
+)
+
+// IsJWK checks whether the dictionary is a JWK object (has "kty" but no "algorithm")
+func IsJWK(info StringKeyMap) bool {
+	if info == nil {
+		return false
+	}
+	_, kty := info["kty"]
+	_, alg := info["algorithm"]
+	return kty && !alg
+}
+
+//
+//  Export
+//
+
+// KeyToJWK converts the cryptography key to JWK object
+//
+// Private keys export both public and private members,
+// public keys export public members only
+//
+// Returns: nil if the key type is not supported, or the key was destroyed
+func KeyToJWK(key CryptographyKey) StringKeyMap {
+	if CheckKey(key) != nil {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
+	var jwk StringKeyMap
+	switch k := key.(type) {
+	case *RSAPrivateKey:
+		jwk = rsaPrivateJWK(k.getPrivateKey())
+	case *RSAPublicKey:
+		jwk = rsaPublicJWK(k.getPublicKey())
+	case *ECCPrivateKey:
+		jwk = eccPrivateJWK(k.curve(), keyBytes(k))
+	case *ECCPublicKey:
+		jwk = eccPublicJWK(k.curve(), eccPublicKeyBytes(k, k.curve()))
+	case *AESKey:
+		jwk = octJWK(keyBytes(k))
+	default:
+		//panic("key type not supported")
+		return nil
+	}
+	if jwk == nil {
+		return nil
+	}
+	// signature algorithm
+	if alg := jwkAlgorithm(jwk, GetDigestAlgorithm(key.Map())); alg != "" {
+		jwk["alg"] = alg
+	}
+	// key ID
+	jwk["kid"] = JWKThumbprint(jwk)
+	return jwk
+}
+
+func rsaPublicJWK(pub *rsa.PublicKey) StringKeyMap {
+	if pub == nil {
+		return nil
+	}
+	jwk := NewMap()
+	jwk["kty"] = JWK_RSA
+	jwk["n"] = jwkEncodeInt(pub.N)
+	jwk["e"] = jwkEncodeInt(big.NewInt(int64(pub.E)))
+	return jwk
+}
+
+func rsaPrivateJWK(pri *rsa.PrivateKey) StringKeyMap {
+	if pri == nil || len(pri.Primes) != 2 {
+		// multi-prime keys ("oth") not supported
+		return nil
+	}
+	pri.Precompute()
+	jwk := rsaPublicJWK(&pri.PublicKey)
+	jwk["d"] = jwkEncodeInt(pri.D)
+	jwk["p"] = jwkEncodeInt(pri.Primes[0])
+	jwk["q"] = jwkEncodeInt(pri.Primes[1])
+	jwk["dp"] = jwkEncodeInt(pri.Precomputed.Dp)
+	jwk["dq"] = jwkEncodeInt(pri.Precomputed.Dq)
+	jwk["qi"] = jwkEncodeInt(pri.Precomputed.Qinv)
+	return jwk
+}
+
+func eccPublicJWK(curve ECCCurve, pub []byte) StringKeyMap {
+	crv := jwkCurveName(curve)
+	if crv == "" || len(pub) != curve.KeySize()*2 {
+		return nil
+	}
+	size := curve.KeySize()
+	jwk := NewMap()
+	jwk["kty"] = JWK_EC
+	jwk["crv"] = crv
+	jwk["x"] = jwkEncode(pub[:size])
+	jwk["y"] = jwkEncode(pub[size:])
+	return jwk
+}
+
+func eccPrivateJWK(curve ECCCurve, pri []byte) StringKeyMap {
+	if curve == nil || len(pri) != curve.KeySize() {
+		return nil
+	}
+	jwk := eccPublicJWK(curve, curve.PublicKey(pri))
+	if jwk != nil {
+		jwk["d"] = jwkEncode(pri)
+	}
+	return jwk
+}
+
+func octJWK(pwd []byte) StringKeyMap {
+	if len(pwd) == 0 {
+		return nil
+	}
+	jwk := NewMap()
+	jwk["kty"] = JWK_OCT
+	jwk["k"] = jwkEncode(pwd)
+	return jwk
+}
+
+// JWKThumbprint calculates the key ID from the required public members (RFC 7638)
+//
+//	kid = base64url(SHA-256(canonical JSON of required members))
+//
+// Returns: empty string if the key type is not supported
+func JWKThumbprint(jwk StringKeyMap) string {
+	var members []string
+	switch ConvertString(jwk["kty"], "") {
+	case JWK_RSA:
+		members = []string{"e", "kty", "n"}
+	case JWK_EC:
+		members = []string{"crv", "kty", "x", "y"}
+	case JWK_OCT:
+		members = []string{"k", "kty"}
+	default:
+		return ""
+	}
+	// encoding/json sorts the map keys without adding any whitespace
+	required := make(map[string]string, len(members))
+	for _, name := range members {
+		required[name] = ConvertString(jwk[name], "")
+	}
+	bin, err := json.Marshal(required)
+	if err != nil {
+		return ""
+	}
+	return jwkEncode(SHA256(bin))
+}
+
+//
+//  Import
+//
+
+// PrivateKeyFromJWK converts the JWK object to private key dictionary
+//
+// Returns: nil if the JWK has no private members or is not supported
+func PrivateKeyFromJWK(jwk StringKeyMap) StringKeyMap {
+	var info StringKeyMap
+	switch ConvertString(jwk["kty"], "") {
+	case JWK_RSA:
+		info = rsaPrivateKeyFromJWK(jwk)
+	case JWK_EC:
+		info = eccPrivateKeyFromJWK(jwk)
+	default:
+		//panic("JWK type not supported")
+		return nil
+	}
+	return jwkKeyInfo(jwk, info)
+}
+
+// PublicKeyFromJWK converts the JWK object to public key dictionary,
+// private members (if any) are ignored
+//
+// Returns: nil if the JWK is not supported
+func PublicKeyFromJWK(jwk StringKeyMap) StringKeyMap {
+	var info StringKeyMap
+	switch ConvertString(jwk["kty"], "") {
+	case JWK_RSA:
+		info = rsaPublicKeyFromJWK(jwk)
+	case JWK_EC:
+		info = eccPublicKeyFromJWK(jwk)
+	default:
+		//panic("JWK type not supported")
+		return nil
+	}
+	return jwkKeyInfo(jwk, info)
+}
+
+// SymmetricKeyFromJWK converts the JWK object ("kty": "oct") to AES key dictionary
+//
+// Returns: nil if the JWK is not a valid AES key
+func SymmetricKeyFromJWK(jwk StringKeyMap) StringKeyMap {
+	if ConvertString(jwk["kty"], "") != JWK_OCT {
+		return nil
+	}
+	pwd := jwkDecode(jwk["k"])
+	switch len(pwd) {
+	case 16, 24, 32:
+	default:
+		//panic("AES key size error")
+		return nil
+	}
+	info := NewMap()
+	info["algorithm"] = AES
+	info["data"] = NewBase64DataWithBytes(pwd).Serialize()
+	return jwkKeyInfo(jwk, info)
+}
+
+// jwkKeyInfo copies the optional "kid" & signature digest ("alg") to the key info
+func jwkKeyInfo(jwk StringKeyMap, info StringKeyMap) StringKeyMap {
+	if info == nil {
+		return nil
+	}
+	if kid, ok := jwk["kid"]; ok {
+		info["kid"] = kid
+	}
+	if digest := jwkDigest(ConvertString(jwk["alg"], "")); digest != "" {
+		info["digest"] = digest
+	}
+	return info
+}
+
+func rsaPublicKeyFromJWK(jwk StringKeyMap) StringKeyMap {
+	pub := jwkRSAPublicKey(jwk)
+	if pub == nil {
+		return nil
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		//panic(err)
+		return nil
+	}
+	block := &pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: der,
+	}
+	info := NewMap()
+	info["algorithm"] = RSA
+	info["data"] = UTF8Decode(pem.EncodeToMemory(block))
+	info["mode"] = "ECB"
+	info["padding"] = "PKCS1"
+	return info
+}
+
+func rsaPrivateKeyFromJWK(jwk StringKeyMap) StringKeyMap {
+	pub := jwkRSAPublicKey(jwk)
+	d := jwkDecodeInt(jwk["d"])
+	p := jwkDecodeInt(jwk["p"])
+	q := jwkDecodeInt(jwk["q"])
+	if pub == nil || d == nil || p == nil || q == nil {
+		// private key without primes not supported
+		return nil
+	}
+	pri := &rsa.PrivateKey{
+		PublicKey: *pub,
+		D:         d,
+		Primes:    []*big.Int{p, q},
+	}
+	if err := pri.Validate(); err != nil {
+		//panic(err)
+		return nil
+	}
+	pri.Precompute()
+	block := &pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: MarshalPKCS8PrivateKey(pri),
+	}
+	info := NewMap()
+	info["algorithm"] = RSA
+	info["data"] = UTF8Decode(pem.EncodeToMemory(block))
+	info["mode"] = "ECB"
+	info["padding"] = "PKCS1"
+	return info
+}
+
+func jwkRSAPublicKey(jwk StringKeyMap) *rsa.PublicKey {
+	n := jwkDecodeInt(jwk["n"])
+	e := jwkDecodeInt(jwk["e"])
+	if n == nil || e == nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil
+	}
+	return &rsa.PublicKey{
+		N: n,
+		E: int(e.Int64()),
+	}
+}
+
+func eccPublicKeyFromJWK(jwk StringKeyMap) StringKeyMap {
+	curve := jwkCurve(jwk)
+	if curve == nil {
+		return nil
+	}
+	x := jwkDecodeFixed(jwk["x"], curve.KeySize())
+	y := jwkDecodeFixed(jwk["y"], curve.KeySize())
+	if x == nil || y == nil {
+		return nil
+	}
+	info := NewMap()
+	info["algorithm"] = ECC
+	info["curve"] = curve.Name()
+	info["data"] = "04" + HexEncode(x) + HexEncode(y)
+	return info
+}
+
+func eccPrivateKeyFromJWK(jwk StringKeyMap) StringKeyMap {
+	curve := jwkCurve(jwk)
+	if curve == nil {
+		return nil
+	}
+	d := jwkDecodeFixed(jwk["d"], curve.KeySize())
+	if d == nil {
+		return nil
+	}
+	info := NewMap()
+	info["algorithm"] = ECC
+	info["curve"] = curve.Name()
+	info["data"] = HexEncode(d)
+	BytesWipe(d)
+	return info
+}
+
+// jwkCurve returns the elliptic curve for the JWK "crv"
+func jwkCurve(jwk StringKeyMap) ECCCurve {
+	switch ConvertString(jwk["crv"], "") {
+	case "secp256k1":
+		return GetECCCurve(CURVE_SECP256K1)
+	case "P-256":
+		return GetECCCurve(CURVE_P256)
+	case "P-384":
+		return GetECCCurve(CURVE_P384)
+	}
+	//panic("JWK curve not supported")
+	return nil
+}
+
+// jwkCurveName returns the JWK "crv" for the elliptic curve
+func jwkCurveName(curve ECCCurve) string {
+	if curve == nil {
+		return ""
+	}
+	switch curve.Name() {
+	case CURVE_SECP256K1:
+		return "secp256k1"
+	case CURVE_P256:
+		return "P-256"
+	case CURVE_P384:
+		return "P-384"
+	}
+	return ""
+}
+
+//
+//  Signature Algorithm
+//
+
+// jwkAlgorithm returns the JWS "alg" for the signature digest (RFC 7518)
+func jwkAlgorithm(jwk StringKeyMap, digest string) string {
+	switch ConvertString(jwk["kty"], "") {
+	case JWK_RSA:
+		switch digest {
+		case "SHA256", "SHA384", "SHA512":
+			return "RS" + digest[3:]
+		}
+	case JWK_EC:
+		switch ConvertString(jwk["crv"], "") + "/" + digest {
+		case "secp256k1/SHA256":
+			return "ES256K"
+		case "P-256/SHA256":
+			return "ES256"
+		case "P-384/SHA384":
+			return "ES384"
+		}
+	}
+	return ""
+}
+
+// jwkDigest returns the signature digest for the JWS "alg"
+func jwkDigest(alg string) string {
+	switch alg {
+	case "RS256", "ES256", "ES256K":
+		return "SHA256"
+	case "RS384", "ES384":
+		return "SHA384"
+	case "RS512", "ES512":
+		return "SHA512"
+	}
+	return ""
+}
+
+//
+//  Base64url
+//
+
+func jwkEncode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func jwkDecode(value any) []byte {
+	text := ConvertString(value, "")
+	if text == "" {
+		return nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(text, "="))
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// jwkEncodeInt encodes unsigned big-endian integer
+func jwkEncodeInt(x *big.Int) string {
+	return jwkEncode(x.Bytes())
+}
+
+func jwkDecodeInt(value any) *big.Int {
+	bin := jwkDecode(value)
+	if len(bin) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(bin)
+}
+
+// jwkDecodeFixed decodes the curve coordinate, which must be exactly the size
+func jwkDecodeFixed(value any, size int) []byte {
+	bin := jwkDecode(value)
+	if len(bin) != size {
+		return nil
+	}
+	return bin
+}
@@ -0,0 +1,125 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package crypto_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/crypto"
+)
+
+// RFC 7638, section 3.1
+func TestJWKThumbprint(t *testing.T) {
+	jwk := NewMap()
+	jwk["kty"] = "RSA"
+	jwk["n"] = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	jwk["e"] = "AQAB"
+	jwk["alg"] = "RS256"
+	jwk["kid"] = "2011-04-29"
+	if kid := JWKThumbprint(jwk); kid != "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs" {
+		t.Errorf("thumbprint = %s", kid)
+	}
+	// the public key imported from JWK exports the same members
+	pKey := ParsePublicKey(PublicKeyFromJWK(jwk))
+	if pKey == nil {
+		t.Fatal("failed to import RSA public key")
+	}
+	out := KeyToJWK(pKey)
+	if out["n"] != jwk["n"] || out["e"] != jwk["e"] || out["kid"] != "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs" {
+		t.Errorf("exported JWK = %v", out)
+	}
+}
+
+func TestJWKPrivateKey(t *testing.T) {
+	keys := map[string]PrivateKey{
+		"RSA":           GeneratePrivateKey(RSA),
+		CURVE_SECP256K1: NewECCPrivateKeyWithCurve(CURVE_SECP256K1),
+		CURVE_P256:      NewECCPrivateKeyWithCurve(CURVE_P256),
+		CURVE_P384:      NewECCPrivateKeyWithCurve(CURVE_P384),
+	}
+	data := []byte("hello")
+	for name, key := range keys {
+		jwk := KeyToJWK(key)
+		if !IsJWK(jwk) || jwk["d"] == nil {
+			t.Fatalf("%s: private JWK = %v", name, jwk)
+		}
+		pub := KeyToJWK(key.PublicKey())
+		if pub["d"] != nil || pub["kid"] != jwk["kid"] {
+			t.Errorf("%s: public JWK = %v, kid = %v", name, pub, jwk["kid"])
+		}
+		// private key round trip
+		sKey := ParsePrivateKey(PrivateKeyFromJWK(jwk))
+		if sKey == nil {
+			t.Fatalf("%s: failed to import private key", name)
+		}
+		if !key.PublicKey().Verify(data, sKey.Sign(data)) {
+			t.Errorf("%s: imported private key not matched", name)
+		}
+		// public key round trip
+		pKey := ParsePublicKey(PublicKeyFromJWK(pub))
+		if pKey == nil {
+			t.Fatalf("%s: failed to import public key", name)
+		}
+		if !pKey.Verify(data, key.Sign(data)) {
+			t.Errorf("%s: imported public key not matched", name)
+		}
+		if out := KeyToJWK(pKey); JWKThumbprint(out) != jwk["kid"] {
+			t.Errorf("%s: thumbprint changed after round trip", name)
+		}
+		// public JWK has no private members
+		if PrivateKeyFromJWK(pub) != nil {
+			t.Errorf("%s: private key imported from public JWK", name)
+		}
+	}
+}
+
+func TestJWKSymmetricKey(t *testing.T) {
+	key := GenerateSymmetricKey(AES)
+	jwk := KeyToJWK(key)
+	if jwk["kty"] != JWK_OCT {
+		t.Fatalf("JWK = %v", jwk)
+	}
+	other := ParseSymmetricKey(SymmetricKeyFromJWK(jwk))
+	if other == nil || !bytes.Equal(other.Data().Bytes(), key.Data().Bytes()) {
+		t.Fatalf("failed to import AES key")
+	}
+	if !MatchEncryptKey(key, other) {
+		t.Errorf("imported AES key not matched")
+	}
+	// AES key size must be 16, 24 or 32 bytes
+	jwk["k"] = "AAECAwQFBgcICQoLDA0ODw" // 16 bytes
+	if SymmetricKeyFromJWK(jwk) == nil {
+		t.Errorf("AES-128 key not imported")
+	}
+	jwk["k"] = "AAECAwQFBgcICQoLDA0O" // 15 bytes
+	if SymmetricKeyFromJWK(jwk) != nil {
+		t.Errorf("15-byte AES key imported")
+	}
+}
@@ -27,11 +27,11 @@ package crypto
 
 import (
 	"bytes"
-	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"math/big"
 
 	. "github.com/dimchat/core-go/format"
 	. "github.com/dimchat/core-go/protocol"
@@ -90,6 +90,7 @@ func NewRSAPrivateKeyWithMap(dict StringKeyMap) IRSAPrivateKey {
 //
 //	KeyInfo JSON Format: {
 //	    "algorithm": "RSA",
+//	    "digest": "SHA256",  // Optional: Signature digest ("SHA256", "SHA384", "SHA512", ...)
 //	    "data": "{BASE64}"   // Base64-encoded raw RSA private key material (PKCS#8 format)
 //	}
 type RSAPrivateKey struct {
@@ -106,6 +107,9 @@ type RSAPrivateKey struct {
 
 	// publicKey caches the corresponding RSAPublicKey derived from this private key
 	publicKey PublicKey
+
+	// destroyed is set after the key material was erased by Destroy()
+	destroyed bool
 }
 
 // Override
@@ -114,12 +118,14 @@ func (key *RSAPrivateKey) Equal(other any) bool {
 }
 
 func (key *RSAPrivateKey) getPrivateKey() *rsa.PrivateKey {
+	if key.destroyed {
+		return nil
+	}
 	if key.rsaPrivateKey == nil {
 		text := key.GetString("data", "")
 		size := len(text)
 		if size == 0 {
 			panic("key data not found")
-			return nil
 		}
 		block, _ := pem.Decode(UTF8Encode(text))
 		pri, err := x509.ParsePKCS1PrivateKey(block.Bytes)
@@ -135,10 +141,6 @@ func (key *RSAPrivateKey) getPrivateKey() *rsa.PrivateKey {
 	return key.rsaPrivateKey
 }
 
-func (key *RSAPrivateKey) getHash() crypto.Hash {
-	return crypto.SHA256
-}
-
 //-------- ICryptographyKey
 
 // Override
@@ -149,12 +151,16 @@ func (key *RSAPrivateKey) Algorithm() string {
 
 // Override
 func (key *RSAPrivateKey) Data() TransportableData {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
 	ted := key.data
 	if ted == nil {
 		// TODO: encode private key data to PKCS1
 		pri := key.getPrivateKey()
 		bin := pri.D.Bytes()
-		ted := NewPlainDataWithBytes(bin)
+		ted = NewPlainDataWithBytes(bin)
 		key.data = ted
 	}
 	return ted
@@ -164,19 +170,36 @@ func (key *RSAPrivateKey) Data() TransportableData {
 
 // Override
 func (key *RSAPrivateKey) Sign(data []byte) []byte {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
 	pri := key.getPrivateKey()
-	h := key.getHash().New()
-	h.Write(data)
-	sum := h.Sum(nil)
-	sig, err := rsa.SignPKCS1v15(rand.Reader, pri, key.getHash(), sum)
+	info := key.Map()
+	hash := signatureHash(info)
+	if hash == 0 {
+		//panic("digest algorithm not supported: " + GetDigestAlgorithm(info))
+		return nil
+	}
+	sum := digestData(info, data)
+	if sum == nil {
+		//panic("digest algorithm not supported: " + GetDigestAlgorithm(info))
+		return nil
+	}
+	sig, err := rsa.SignPKCS1v15(rand.Reader, pri, hash, sum)
 	if err != nil {
-		panic(err)
+		//panic(err)
+		return nil
 	}
 	return sig
 }
 
 // Override
 func (key *RSAPrivateKey) PublicKey() PublicKey {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
 	publicKey := key.publicKey
 	if publicKey == nil {
 		sKey := key.getPrivateKey()
@@ -197,7 +220,7 @@ func (key *RSAPrivateKey) PublicKey() PublicKey {
 		info["data"] = txt
 		info["mode"] = "ECB"
 		info["padding"] = "PKCS1"
-		info["digest"] = "SHA256"
+		info["digest"] = GetDigestAlgorithm(key.Map())
 		publicKey = &RSAPublicKey{
 			Dictionary:   NewDictionary(info),
 			rsaPublicKey: pKey,
@@ -212,6 +235,10 @@ func (key *RSAPrivateKey) PublicKey() PublicKey {
 
 // Override
 func (key *RSAPrivateKey) Decrypt(ciphertext []byte, _ StringKeyMap) []byte {
+	if key.destroyed {
+		//panic(ErrKeyDestroyed)
+		return nil
+	}
 	pri := key.getPrivateKey()
 	part := pri.N.BitLen() / 8
 	chunks := BytesSplit(ciphertext, part)
@@ -230,3 +257,47 @@ func (key *RSAPrivateKey) Decrypt(ciphertext []byte, _ StringKeyMap) []byte {
 func (key *RSAPrivateKey) MatchEncryptKey(pKey EncryptKey) bool {
 	return MatchEncryptKey(pKey, key)
 }
+
+//-------- IDestroyableKey
+
+// Override
+func (key *RSAPrivateKey) Destroy() {
+	pri := key.rsaPrivateKey
+	if pri != nil {
+		wipeBigInt(pri.D)
+		for _, prime := range pri.Primes {
+			wipeBigInt(prime)
+		}
+		wipeBigInt(pri.Precomputed.Dp)
+		wipeBigInt(pri.Precomputed.Dq)
+		wipeBigInt(pri.Precomputed.Qinv)
+		for _, crt := range pri.Precomputed.CRTValues {
+			wipeBigInt(crt.Exp)
+			wipeBigInt(crt.Coeff)
+			wipeBigInt(crt.R)
+		}
+	}
+	wipeData(key.data)
+	key.rsaPrivateKey = nil
+	key.data = nil
+	key.publicKey = nil
+	key.Remove("data")
+	key.destroyed = true
+}
+
+// Override
+func (key *RSAPrivateKey) IsDestroyed() bool {
+	return key.destroyed
+}
+
+// wipeBigInt zeroes the words of a big integer in place
+func wipeBigInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+	words := x.Bits()
+	for index := range words {
+		words[index] = 0
+	}
+	x.SetInt64(0)
+}
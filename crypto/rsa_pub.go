@@ -27,7 +27,6 @@ package crypto
 
 import (
 	"bytes"
-	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -60,6 +59,7 @@ func NewRSAPublicKeyWithMap(dict StringKeyMap) IRSAPublicKey {
 //
 //	KeyInfo JSON Format: {
 //	    "algorithm": "RSA",
+//	    "digest": "SHA256",  // Optional: Signature digest (matches private key digest)
 //	    "data": "{BASE64}"   // Base64-encoded raw RSA public key material (PKCS#1 format)
 //	}
 type RSAPublicKey struct {
@@ -88,10 +88,6 @@ func (key *RSAPublicKey) getPublicKey() *rsa.PublicKey {
 	return key.rsaPublicKey
 }
 
-func (key *RSAPublicKey) getHash() crypto.Hash {
-	return crypto.SHA256
-}
-
 //-------- ICryptographyKey
 
 // Override
@@ -118,10 +114,18 @@ func (key *RSAPublicKey) Data() TransportableData {
 // Override
 func (key *RSAPublicKey) Verify(data []byte, signature []byte) bool {
 	pub := key.getPublicKey()
-	h := key.getHash().New()
-	h.Write(data)
-	sum := h.Sum(nil)
-	err := rsa.VerifyPKCS1v15(pub, key.getHash(), sum, signature)
+	info := key.Map()
+	hash := signatureHash(info)
+	if hash == 0 {
+		//panic("digest algorithm not supported")
+		return false
+	}
+	sum := digestData(info, data)
+	if sum == nil {
+		//panic("digest algorithm not supported")
+		return false
+	}
+	err := rsa.VerifyPKCS1v15(pub, hash, sum, signature)
 	return err == nil
 }
 
@@ -149,3 +149,51 @@ func SignatureFromDER(der []byte) []byte {
 	C.ecc_der_to_sig(derPtr, C.int(cnt), sigPtr)
 	return sig
 }
+
+// SharedSecret computes the ECDH shared secret from a peer's public key and our private key
+//
+// Wraps the uECC_valid_public_key & uECC_shared_secret C functions from micro-ecc library
+// The result is the raw X coordinate of the shared point, it should be hashed (KDF)
+// before being used as a symmetric key
+//
+// Parameters:
+//   - pub - 64-byte ECC public key of the peer (secp256k1 curve)
+//   - pri - 32-byte ECC private key (secp256k1 curve)
+//
+// Returns: 32-byte shared secret if succeeds, nil if the public key is invalid
+func SharedSecret(pub, pri []byte) []byte {
+	if len(pub) != 64 || len(pri) != 32 {
+		return nil
+	}
+	secret := make([]byte, 32)
+	pubPtr := (*C.uchar)(unsafe.Pointer(&pub[0]))
+	priPtr := (*C.uchar)(unsafe.Pointer(&pri[0]))
+	secPtr := (*C.uchar)(unsafe.Pointer(&secret[0]))
+	if C.uECC_valid_public_key(pubPtr, C.uECC_secp256k1()) != 1 {
+		return nil
+	}
+	res := C.uECC_shared_secret(pubPtr, priPtr, secPtr, C.uECC_secp256k1())
+	if res == 1 {
+		return secret
+	}
+	return nil
+}
+
+// Decompress converts a 33-byte compressed public key to the 64-byte uncompressed form
+//
+// Wraps the uECC_decompress C function from micro-ecc library
+//
+// Parameters:
+//   - compressed - 33-byte compressed ECC public key (0x02/0x03 prefix + X)
+//
+// Returns: 64-byte ECC public key (X + Y, without 0x04 prefix), nil if the input is invalid
+func Decompress(compressed []byte) []byte {
+	if len(compressed) != 33 || (compressed[0] != 0x02 && compressed[0] != 0x03) {
+		return nil
+	}
+	pub := make([]byte, 64)
+	cmpPtr := (*C.uchar)(unsafe.Pointer(&compressed[0]))
+	pubPtr := (*C.uchar)(unsafe.Pointer(&pub[0]))
+	C.uECC_decompress(cmpPtr, pubPtr, C.uECC_secp256k1())
+	return pub
+}
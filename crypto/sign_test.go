@@ -0,0 +1,81 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package crypto_test
+
+import (
+	"testing"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/types"
+)
+
+// withDigest reloads the private key with the "digest" field replaced
+func withDigest(t *testing.T, key PrivateKey, digest string) (PrivateKey, PublicKey) {
+	info := CopyMap(key.Map())
+	info["digest"] = digest
+	sKey := ParsePrivateKey(info)
+	if sKey == nil {
+		t.Fatalf("failed to parse key with digest: %s", digest)
+	}
+	return sKey, sKey.PublicKey()
+}
+
+func TestSignatureDigest(t *testing.T) {
+	data := []byte("hello")
+	rsaKey := GeneratePrivateKey(RSA)
+	eccKey := GeneratePrivateKey(ECC)
+	supported := map[PrivateKey][]string{
+		rsaKey: {"SHA256", "SHA512", "SHA1"},
+		eccKey: {"SHA256", "SHA-512", "SHA3-256", "KECCAK-256", "BLAKE2b-256"},
+	}
+	for key, digests := range supported {
+		for _, digest := range digests {
+			sKey, pKey := withDigest(t, key, digest)
+			signature := sKey.Sign(data)
+			if signature == nil || !pKey.Verify(data, signature) {
+				t.Errorf("%s with %s: signature not verified", key.Algorithm(), digest)
+			}
+		}
+	}
+	unsupported := map[PrivateKey][]string{
+		rsaKey: {"SHA3-256", "SHA3-512", "KECCAK-256", "BLAKE2b-256", "UNKNOWN"},
+		eccKey: {"UNKNOWN"},
+	}
+	for key, digests := range unsupported {
+		// signature from the default digest must not verify either
+		signature := key.Sign(data)
+		for _, digest := range digests {
+			sKey, pKey := withDigest(t, key, digest)
+			if sKey.Sign(data) != nil {
+				t.Errorf("%s with %s: Sign() not nil", key.Algorithm(), digest)
+			}
+			if pKey != nil && pKey.Verify(data, signature) {
+				t.Errorf("%s with %s: Verify() = true", key.Algorithm(), digest)
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package digest
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+
+	. "github.com/dimchat/mkm-go/digest"
+)
+
+func NewBLAKE2b256Digester() MessageDigester {
+	return &BLAKE2bDigester{
+		size: 32,
+	}
+}
+
+func NewBLAKE2b512Digester() MessageDigester {
+	return &BLAKE2bDigester{
+		size: 64,
+	}
+}
+
+// BLAKE2bDigester implements unkeyed BLAKE2b-256 / BLAKE2b-512 (RFC 7693)
+type BLAKE2bDigester struct {
+	//MessageDigester
+
+	// output size in bytes
+	size int
+}
+
+// Override
+func (digester BLAKE2bDigester) Digest(data []byte) []byte {
+	hash := digester.New()
+	hash.Write(data)
+	return hash.Sum(nil)
+}
+
+// Override
+func (digester BLAKE2bDigester) New() hash.Hash {
+	return newBLAKE2b(digester.size)
+}
+
+//
+//  BLAKE2b
+//
+
+const blake2bBlockSize = 128
+
+var blake2bIV = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+var blake2bSigma = [12][16]uint8{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+}
+
+type blake2b struct {
+	//hash.Hash
+
+	h    [8]uint64
+	t    uint64 // bytes compressed (messages over 2^64 bytes not supported)
+	buf  [blake2bBlockSize]byte
+	n    int
+	size int
+}
+
+func newBLAKE2b(size int) hash.Hash {
+	d := &blake2b{size: size}
+	d.Reset()
+	return d
+}
+
+// Override
+func (d *blake2b) Reset() {
+	d.h = blake2bIV
+	// parameter block: digest length, no key, fanout = depth = 1
+	d.h[0] ^= 0x01010000 ^ uint64(d.size)
+	d.t = 0
+	d.n = 0
+}
+
+// Override
+func (d *blake2b) Size() int {
+	return d.size
+}
+
+// Override
+func (d *blake2b) BlockSize() int {
+	return blake2bBlockSize
+}
+
+// Override
+func (d *blake2b) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// the last block must be kept for finalization
+		if d.n == blake2bBlockSize {
+			d.t += blake2bBlockSize
+			d.compress(d.buf[:], false)
+			d.n = 0
+		}
+		c := copy(d.buf[d.n:], p)
+		d.n += c
+		p = p[c:]
+	}
+	return n, nil
+}
+
+// Override
+func (d *blake2b) Sum(in []byte) []byte {
+	// make a copy, so the caller can keep writing
+	c := *d
+	for i := c.n; i < blake2bBlockSize; i++ {
+		c.buf[i] = 0
+	}
+	c.t += uint64(c.n)
+	c.compress(c.buf[:], true)
+	var out [64]byte
+	for i, v := range c.h {
+		binary.LittleEndian.PutUint64(out[i*8:], v)
+	}
+	return append(in, out[:c.size]...)
+}
+
+func (d *blake2b) compress(block []byte, last bool) {
+	var m [16]uint64
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(block[i*8:])
+	}
+	var v [16]uint64
+	copy(v[:8], d.h[:])
+	copy(v[8:], blake2bIV[:])
+	v[12] ^= d.t
+	if last {
+		v[14] = ^v[14]
+	}
+	for _, s := range blake2bSigma {
+		blake2bG(&v, 0, 4, 8, 12, m[s[0]], m[s[1]])
+		blake2bG(&v, 1, 5, 9, 13, m[s[2]], m[s[3]])
+		blake2bG(&v, 2, 6, 10, 14, m[s[4]], m[s[5]])
+		blake2bG(&v, 3, 7, 11, 15, m[s[6]], m[s[7]])
+		blake2bG(&v, 0, 5, 10, 15, m[s[8]], m[s[9]])
+		blake2bG(&v, 1, 6, 11, 12, m[s[10]], m[s[11]])
+		blake2bG(&v, 2, 7, 8, 13, m[s[12]], m[s[13]])
+		blake2bG(&v, 3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+	for i := range d.h {
+		d.h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+func blake2bG(v *[16]uint64, a, b, c, d int, x, y uint64) {
+	v[a] = v[a] + v[b] + x
+	v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+	v[c] = v[c] + v[d]
+	v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+	v[a] = v[a] + v[b] + y
+	v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+	v[c] = v[c] + v[d]
+	v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+}
@@ -0,0 +1,70 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package digest
+
+import (
+	"strings"
+
+	. "github.com/dimchat/mkm-go/digest"
+)
+
+//
+//  Digester Registry
+//
+
+var sharedDigesters = make(map[string]MessageDigester, 16)
+
+// SetDigester registers a message digester for the algorithm name
+//
+// Parameters:
+//   - algorithm - digest algorithm name, e.g.: "SHA-256", "SHA256", "sha256"
+//   - digester  - digester for this algorithm
+func SetDigester(algorithm string, digester MessageDigester) {
+	name := DigestAlgorithm(algorithm)
+	sharedDigesters[name] = digester
+}
+
+// GetDigester returns the message digester registered for the algorithm name
+//
+// Returns: nil if the algorithm is not supported
+func GetDigester(algorithm string) MessageDigester {
+	name := DigestAlgorithm(algorithm)
+	return sharedDigesters[name]
+}
+
+// DigestAlgorithm normalizes the digest algorithm name
+//
+// Upper cases the name and removes all separators, so that
+// "SHA-256", "sha256" & "SHA_256" are all treated as "SHA256"
+func DigestAlgorithm(name string) string {
+	name = strings.ToUpper(name)
+	return strings.Map(func(ch rune) rune {
+		if ch == '-' || ch == '_' || ch == ' ' || ch == '/' {
+			return -1
+		}
+		return ch
+	}, name)
+}
@@ -0,0 +1,169 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package digest
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+//
+//  Keccak sponge (FIPS 202) over the Keccak-f[1600] permutation
+//
+//  Keccak-256 (Ethereum) & SHA3-256 differ only in the domain separation
+//  byte appended to the message: 0x01 for Keccak, 0x06 for SHA-3
+//
+
+const (
+	keccakDomain = 0x01
+	sha3Domain   = 0x06
+)
+
+type keccak struct {
+	//hash.Hash
+
+	a      [25]uint64
+	buf    [200]byte
+	n      int  // bytes in buf
+	rate   int  // bytes absorbed per permutation
+	size   int  // output size in bytes
+	domain byte // domain separation byte
+}
+
+// newKeccak creates sponge with capacity = 2 * size
+func newKeccak(size int, domain byte) hash.Hash {
+	return &keccak{
+		rate:   200 - 2*size,
+		size:   size,
+		domain: domain,
+	}
+}
+
+// Override
+func (d *keccak) Reset() {
+	d.a = [25]uint64{}
+	d.n = 0
+}
+
+// Override
+func (d *keccak) Size() int {
+	return d.size
+}
+
+// Override
+func (d *keccak) BlockSize() int {
+	return d.rate
+}
+
+// Override
+func (d *keccak) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		c := copy(d.buf[d.n:d.rate], p)
+		d.n += c
+		p = p[c:]
+		if d.n == d.rate {
+			d.absorb()
+		}
+	}
+	return n, nil
+}
+
+// Override
+func (d *keccak) Sum(in []byte) []byte {
+	// make a copy, so the caller can keep writing
+	c := *d
+	// pad10*1 with domain separation
+	for i := c.n; i < c.rate; i++ {
+		c.buf[i] = 0
+	}
+	c.buf[c.n] ^= c.domain
+	c.buf[c.rate-1] ^= 0x80
+	c.absorb()
+	// squeeze (size < rate for all supported variants)
+	out := make([]byte, c.rate)
+	for i := 0; i < c.rate/8; i++ {
+		binary.LittleEndian.PutUint64(out[i*8:], c.a[i])
+	}
+	return append(in, out[:c.size]...)
+}
+
+func (d *keccak) absorb() {
+	for i := 0; i < d.rate/8; i++ {
+		d.a[i] ^= binary.LittleEndian.Uint64(d.buf[i*8:])
+	}
+	keccakF1600(&d.a)
+	d.n = 0
+}
+
+var keccakRC = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// rotation offsets & lane positions for the rho and pi steps
+var (
+	keccakRho = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+	keccakPi  = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+)
+
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+	for round := 0; round < 24; round++ {
+		// theta
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			t := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= t
+			}
+		}
+		// rho & pi
+		t := a[1]
+		for i := 0; i < 24; i++ {
+			j := keccakPi[i]
+			t, a[j] = a[j], bits.RotateLeft64(t, keccakRho[i])
+		}
+		// chi
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				c[x] = a[y+x]
+			}
+			for x := 0; x < 5; x++ {
+				a[y+x] = c[x] ^ (^c[(x+1)%5] & c[(x+2)%5])
+			}
+		}
+		// iota
+		a[0] ^= keccakRC[round]
+	}
+}
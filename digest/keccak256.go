@@ -25,22 +25,28 @@
  */
 package digest
 
-//import (
-//	. "github.com/dimchat/mkm-go/digest"
-//	"golang.org/x/crypto/sha3"
-//)
-//
-//func NewKECCAK256Digester() MessageDigester {
-//	return &KECCAK256Digester{}
-//}
-//
-//type KECCAK256Digester struct {
-//	//MessageDigester
-//}
-//
-//// Override
-//func (digester KECCAK256Digester) Digest(data []byte) []byte {
-//	hash := sha3.NewLegacyKeccak256()
-//	hash.Write(data)
-//	return hash.Sum(nil)
-//}
+import (
+	"hash"
+
+	. "github.com/dimchat/mkm-go/digest"
+)
+
+func NewKECCAK256Digester() MessageDigester {
+	return &KECCAK256Digester{}
+}
+
+type KECCAK256Digester struct {
+	//MessageDigester
+}
+
+// Override
+func (KECCAK256Digester) Digest(data []byte) []byte {
+	hash := newKeccak(32, keccakDomain)
+	hash.Write(data)
+	return hash.Sum(nil)
+}
+
+// Override
+func (KECCAK256Digester) New() hash.Hash {
+	return newKeccak(32, keccakDomain)
+}
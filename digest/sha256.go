@@ -27,6 +27,7 @@ package digest
 
 import (
 	"crypto/sha256"
+	"hash"
 
 	. "github.com/dimchat/mkm-go/digest"
 )
@@ -44,3 +45,8 @@ func (SHA256Digester) Digest(data []byte) []byte {
 	hash := sha256.Sum256(data)
 	return hash[:]
 }
+
+// Override
+func (SHA256Digester) New() hash.Hash {
+	return sha256.New()
+}
@@ -0,0 +1,64 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package digest
+
+import (
+	"hash"
+
+	. "github.com/dimchat/mkm-go/digest"
+)
+
+func NewSHA3256Digester() MessageDigester {
+	return &SHA3Digester{
+		size: 32,
+	}
+}
+
+func NewSHA3512Digester() MessageDigester {
+	return &SHA3Digester{
+		size: 64,
+	}
+}
+
+// SHA3Digester implements SHA3-256 / SHA3-512 (FIPS 202)
+type SHA3Digester struct {
+	//MessageDigester
+
+	// output size in bytes
+	size int
+}
+
+// Override
+func (digester SHA3Digester) Digest(data []byte) []byte {
+	hash := digester.New()
+	hash.Write(data)
+	return hash.Sum(nil)
+}
+
+// Override
+func (digester SHA3Digester) New() hash.Hash {
+	return newKeccak(digester.size, sha3Domain)
+}
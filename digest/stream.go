@@ -0,0 +1,86 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package digest
+
+import (
+	"hash"
+	"io"
+
+	. "github.com/dimchat/mkm-go/digest"
+)
+
+// StreamDigester is a message digester that can hash data incrementally,
+// so large payloads (e.g.: file attachments) need not be loaded into memory
+//
+// All digesters in this package implement it, and the result of the stream
+// is the same as the one-shot Digest()
+type StreamDigester interface {
+	MessageDigester
+
+	// New creates a hash state for incremental writing
+	New() hash.Hash
+}
+
+// GetStreamDigester returns the streaming digester registered for the algorithm name
+//
+// Returns: nil if the algorithm is not supported, or the digester cannot stream
+func GetStreamDigester(algorithm string) StreamDigester {
+	digester := GetDigester(algorithm)
+	if sd, ok := digester.(StreamDigester); ok {
+		return sd
+	}
+	return nil
+}
+
+// NewHash creates a hash state with the digester registered for the algorithm name
+//
+// Returns: nil if the algorithm is not supported, or the digester cannot stream
+func NewHash(algorithm string) hash.Hash {
+	digester := GetStreamDigester(algorithm)
+	if digester == nil {
+		return nil
+	}
+	return digester.New()
+}
+
+// DigestReader calculates the digest of all data from the reader
+//
+// Digesters without streaming support fall back to reading all data
+// into memory and calling Digest()
+func DigestReader(digester MessageDigester, reader io.Reader) ([]byte, error) {
+	if sd, ok := digester.(StreamDigester); ok {
+		hash := sd.New()
+		if _, err := io.Copy(hash, reader); err != nil {
+			return nil, err
+		}
+		return hash.Sum(nil), nil
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return digester.Digest(data), nil
+}
@@ -0,0 +1,155 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package digest
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	. "github.com/dimchat/mkm-go/digest"
+)
+
+var streamDigesters = map[string]StreamDigester{
+	"MD5":         NewMD5Digester().(StreamDigester),
+	"SHA-1":       NewSHA1Digester().(StreamDigester),
+	"SHA-256":     NewSHA256Digester().(StreamDigester),
+	"SHA-512":     NewSHA512Digester().(StreamDigester),
+	"SHA3-256":    NewSHA3256Digester().(StreamDigester),
+	"SHA3-512":    NewSHA3512Digester().(StreamDigester),
+	"BLAKE2b-256": NewBLAKE2b256Digester().(StreamDigester),
+	"BLAKE2b-512": NewBLAKE2b512Digester().(StreamDigester),
+	"RIPEMD-160":  NewRIPEMD160Digester().(StreamDigester),
+	"KECCAK-256":  NewKECCAK256Digester().(StreamDigester),
+}
+
+func testData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i*7 + i>>8)
+	}
+	return data
+}
+
+// writeChunks writes the data to the hash in chunks of the given size
+func writeChunks(t *testing.T, digester StreamDigester, data []byte, chunk int) []byte {
+	h := digester.New()
+	for i := 0; i < len(data); i += chunk {
+		end := i + chunk
+		if end > len(data) {
+			end = len(data)
+		}
+		if n, err := h.Write(data[i:end]); n != end-i || err != nil {
+			t.Fatalf("Write() = %d, %v", n, err)
+		}
+	}
+	return h.Sum(nil)
+}
+
+func TestStreamDigest(t *testing.T) {
+	// sizes around the block sizes (64, 72, 128, 136)
+	sizes := []int{0, 1, 55, 63, 64, 65, 71, 72, 73, 127, 128, 129, 135, 136, 137, 1000}
+	for name, digester := range streamDigesters {
+		for _, size := range sizes {
+			data := testData(size)
+			expected := digester.Digest(data)
+			for _, chunk := range []int{1, 7, 64, 1000} {
+				if out := writeChunks(t, digester, data, chunk); !bytes.Equal(out, expected) {
+					t.Errorf("%s: size %d, chunk %d: %x != %x", name, size, chunk, out, expected)
+				}
+			}
+		}
+	}
+}
+
+func TestStreamState(t *testing.T) {
+	data := testData(300)
+	for name, digester := range streamDigesters {
+		h := digester.New()
+		if h.Size() != len(digester.Digest(nil)) || h.BlockSize() <= 0 {
+			t.Errorf("%s: Size() = %d, BlockSize() = %d", name, h.Size(), h.BlockSize())
+		}
+		// Sum() appends to the input and does not change the state
+		h.Write(data[:100])
+		prefix := []byte("prefix")
+		sum := h.Sum(prefix)
+		if !bytes.Equal(sum[:len(prefix)], prefix) || !bytes.Equal(sum[len(prefix):], digester.Digest(data[:100])) {
+			t.Errorf("%s: Sum() = %x", name, sum)
+		}
+		h.Write(data[100:])
+		if out := h.Sum(nil); !bytes.Equal(out, digester.Digest(data)) {
+			t.Errorf("%s: Write() after Sum() = %x", name, out)
+		}
+		// Reset() starts over
+		h.Reset()
+		h.Write(data[:10])
+		if out := h.Sum(nil); !bytes.Equal(out, digester.Digest(data[:10])) {
+			t.Errorf("%s: Write() after Reset() = %x", name, out)
+		}
+	}
+}
+
+// oneShotDigester cannot stream
+type oneShotDigester struct{}
+
+func (oneShotDigester) Digest(data []byte) []byte {
+	return NewSHA256Digester().Digest(data)
+}
+
+func TestDigestReader(t *testing.T) {
+	data := testData(5000)
+	for name, digester := range streamDigesters {
+		out, err := DigestReader(digester, bytes.NewReader(data))
+		if err != nil || !bytes.Equal(out, digester.Digest(data)) {
+			t.Errorf("%s: DigestReader() = %x, %v", name, out, err)
+		}
+	}
+	// falls back to Digest()
+	var digester MessageDigester = oneShotDigester{}
+	out, err := DigestReader(digester, bytes.NewReader(data))
+	if err != nil || !bytes.Equal(out, digester.Digest(data)) {
+		t.Errorf("one-shot: DigestReader() = %x, %v", out, err)
+	}
+}
+
+func TestNewHash(t *testing.T) {
+	SetDigester("SHA-256", NewSHA256Digester())
+	SetDigester("ONE-SHOT", oneShotDigester{})
+	h := NewHash("sha256")
+	if h == nil {
+		t.Fatal("NewHash() = nil")
+	}
+	h.Write([]byte("abc"))
+	if out := hex.EncodeToString(h.Sum(nil)); out != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+		t.Errorf("SHA-256(abc) = %s", out)
+	}
+	if NewHash("ONE-SHOT") != nil || GetStreamDigester("ONE-SHOT") != nil {
+		t.Errorf("one-shot digester streaming")
+	}
+	if NewHash("UNKNOWN") != nil {
+		t.Errorf("unknown digester streaming")
+	}
+}
@@ -32,6 +32,7 @@ import (
 	. "github.com/dimchat/mkm-go/format"
 	. "github.com/dimchat/mkm-go/protocol"
 	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/schema"
 )
 
 type IAccountGeneralFactory interface {
@@ -213,6 +214,11 @@ func (gf *AccountGeneralFactory) ParseMeta(meta any) Meta {
 		//panic("meta error")
 		return nil
 	}
+	// check schema in strict mode
+	if !CheckSchema(META_SCHEMA, info) {
+		//panic("meta format error")
+		return nil
+	}
 	version := gf.GetMetaType(info, "")
 	factory := gf.GetMetaFactory(version)
 	if factory == nil {
@@ -258,6 +264,11 @@ func (gf *AccountGeneralFactory) ParseDocument(doc any) Document {
 		//panic("document error")
 		return nil
 	}
+	// check schema in strict mode
+	if !CheckSchema(DOCUMENT_SCHEMA, info) {
+		//panic("document format error")
+		return nil
+	}
 	docType := gf.GetDocumentType(info, "")
 	factory := gf.GetDocumentFactory(docType)
 	if factory == nil {
@@ -56,6 +56,26 @@ func (aesFactory) ParseSymmetricKey(key StringKeyMap) SymmetricKey {
 	return NewAESKeyWithMap(key)
 }
 
+type aesHMACFactory struct {
+	//SymmetricKeyFactory
+}
+
+// Override
+func (aesHMACFactory) GenerateSymmetricKey() SymmetricKey {
+	return NewAESHMACKey()
+}
+
+// Override
+func (aesHMACFactory) ParseSymmetricKey(key StringKeyMap) SymmetricKey {
+	// check 'data', 'algorithm'
+	if !ContainsKey(key, "data") || !ContainsKey(key, "algorithm") {
+		// key.data should not be empty
+		// key.algorithm should not be empty
+		return nil
+	}
+	return NewAESHMACKeyWithMap(key)
+}
+
 type plainFactory struct {
 	//SymmetricKeyFactory
 }
@@ -31,6 +31,7 @@ import (
 	. "github.com/dimchat/dkd-go/ext"
 	. "github.com/dimchat/dkd-go/protocol"
 	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/schema"
 )
 
 type ICommandGeneralFactory interface {
@@ -82,6 +83,11 @@ func (gf *CommandGeneralFactory) ParseCommand(content any) Command {
 		//panic("command error")
 		return nil
 	}
+	// check schema in strict mode
+	if !CheckContent(info) {
+		//panic("command format error")
+		return nil
+	}
 	// get factory by command name
 	cmd := gf.GetCMD(info, "")
 	factory := gf.GetCommandFactory(cmd)
@@ -29,6 +29,7 @@ import (
 	. "github.com/dimchat/mkm-go/crypto"
 	. "github.com/dimchat/mkm-go/ext"
 	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/crypto"
 )
 
 type ICryptoKeyGeneralFactory interface {
@@ -95,6 +96,13 @@ func (gf *CryptoKeyGeneralFactory) ParseSymmetricKey(key any) SymmetricKey {
 	if info == nil {
 		//panic("symmetric key error")
 		return nil
+	} else if IsJWK(info) {
+		// convert JSON Web Key
+		info = SymmetricKeyFromJWK(info)
+		if info == nil {
+			//panic("JWK error")
+			return nil
+		}
 	}
 	algorithm := gf.GetKeyAlgorithm(info, "")
 	factory := gf.GetSymmetricKeyFactory(algorithm)
@@ -140,6 +148,13 @@ func (gf *CryptoKeyGeneralFactory) ParsePrivateKey(key any) PrivateKey {
 	if info == nil {
 		//panic("private key error")
 		return nil
+	} else if IsJWK(info) {
+		// convert JSON Web Key
+		info = PrivateKeyFromJWK(info)
+		if info == nil {
+			//panic("JWK error")
+			return nil
+		}
 	}
 	algorithm := gf.GetKeyAlgorithm(info, "")
 	factory := gf.GetPrivateKeyFactory(algorithm)
@@ -178,6 +193,13 @@ func (gf *CryptoKeyGeneralFactory) ParsePublicKey(key any) PublicKey {
 	info := FetchMap(key)
 	if info == nil {
 		return nil
+	} else if IsJWK(info) {
+		// convert JSON Web Key
+		info = PublicKeyFromJWK(info)
+		if info == nil {
+			//panic("JWK error")
+			return nil
+		}
 	}
 	algorithm := gf.GetKeyAlgorithm(info, "")
 	factory := gf.GetPublicKeyFactory(algorithm)
@@ -33,7 +33,12 @@ import (
 )
 
 //goland:noinspection GoSnakeCaseUsage
-const ECDSA_SHA256 = "SHA256withECDSA"
+const (
+	ECDSA_SHA256    = "SHA256withECDSA"
+	ECDSA_SHA512    = "SHA512withECDSA"
+	ECDSA_SHA3_256  = "SHA3-256withECDSA"
+	ECDSA_KECCAK256 = "KECCAK256withECDSA"
+)
 
 type eccPrivateFactory struct {
 	//PrivateKeyFactory
@@ -51,6 +56,9 @@ func (eccPrivateFactory) ParsePrivateKey(key StringKeyMap) PrivateKey {
 		// key.data should not be empty
 		// key.algorithm should not be empty
 		return nil
+	} else if GetKeyCurve(key) == nil {
+		// curve not supported
+		return nil
 	}
 	return NewECCPrivateKeyWithMap(key)
 }
@@ -66,6 +74,9 @@ func (eccPublicFactory) ParsePublicKey(key StringKeyMap) PublicKey {
 		// key.data should not be empty
 		// key.algorithm should not be empty
 		return nil
+	} else if GetKeyCurve(key) == nil {
+		// curve not supported
+		return nil
 	}
 	return NewECCPublicKeyWithMap(key)
 }
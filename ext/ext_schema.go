@@ -0,0 +1,97 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package ext
+
+import (
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/plugins-go/schema"
+)
+
+/**
+ *  Core schemas
+ */
+
+func registerSchemas() {
+
+	// Envelope & Messages
+	SetSchema(ENVELOPE_SCHEMA, EnvelopeSchema)
+	SetSchema(INSTANT_SCHEMA, InstantMessageSchema)
+	SetSchema(SECURE_SCHEMA, SecureMessageSchema)
+	SetSchema(RELIABLE_SCHEMA, ReliableMessageSchema)
+
+	// Meta & Document
+	SetSchema(META_SCHEMA, MetaSchema)
+	SetSchema(DOCUMENT_SCHEMA, DocumentSchema)
+
+	// Contents
+	registerContentSchema(ContentType.TEXT, TextContentSchema)
+
+	registerContentSchema(ContentType.FILE, FileContentSchema)
+	registerContentSchema(ContentType.IMAGE, ImageContentSchema)
+	registerContentSchema(ContentType.AUDIO, AudioContentSchema)
+	registerContentSchema(ContentType.VIDEO, VideoContentSchema)
+
+	registerContentSchema(ContentType.PAGE, PageContentSchema)
+	registerContentSchema(ContentType.NAME_CARD, NameCardSchema)
+	registerContentSchema(ContentType.QUOTE, QuoteContentSchema)
+
+	registerContentSchema(ContentType.MONEY, MoneyContentSchema)
+	registerContentSchema(ContentType.TRANSFER, TransferContentSchema)
+
+	registerContentSchema(ContentType.COMMAND, CommandSchema)
+	registerContentSchema(ContentType.HISTORY, HistoryCommandSchema)
+
+	registerContentSchema(ContentType.ARRAY, ArrayContentSchema)
+	registerContentSchema(ContentType.COMBINE_FORWARD, CombineContentSchema)
+	registerContentSchema(ContentType.FORWARD, ForwardContentSchema)
+
+	// unknown content type
+	registerContentSchema(ContentType.ANY, ContentSchema)
+	registerContentSchema("*", ContentSchema)
+
+	// Commands
+	registerCommandSchema(META, MetaCommandSchema)
+	registerCommandSchema(DOCUMENTS, DocumentCommandSchema)
+	registerCommandSchema(RECEIPT, ReceiptCommandSchema)
+
+	// Group Commands
+	registerCommandSchema("group", GroupCommandSchema)
+
+	registerCommandSchema(INVITE, InviteCommandSchema)
+	registerCommandSchema(EXPEL, ExpelCommandSchema)
+	registerCommandSchema(JOIN, JoinCommandSchema)
+	registerCommandSchema(QUIT, QuitCommandSchema)
+	registerCommandSchema(RESET, ResetCommandSchema)
+
+}
+
+func registerContentSchema(msgType string, schema *Schema) {
+	SetSchema(ContentSchemaName(msgType), schema)
+}
+
+func registerCommandSchema(cmd string, schema *Schema) {
+	SetSchema(CommandSchemaName(cmd), schema)
+}
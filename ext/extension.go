@@ -58,6 +58,8 @@ func (loader ExtensionLoader) Load() {
 	loader.RegisterContentFactories()
 	loader.RegisterCommandFactories()
 
+	loader.RegisterSchemas()
+
 }
 
 /**
@@ -136,3 +138,12 @@ func (loader ExtensionLoader) RegisterContentFactories() {
 func (loader ExtensionLoader) RegisterCommandFactories() {
 	registerCommandFactories()
 }
+
+/**
+ *  Core schemas
+ */
+
+// protected
+func (loader ExtensionLoader) RegisterSchemas() {
+	registerSchemas()
+}
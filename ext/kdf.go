@@ -0,0 +1,67 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package ext
+
+import (
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/kdf"
+	. "github.com/dimchat/plugins-go/mem"
+)
+
+type hkdfFactory struct {
+	//KeyDerivationFunctionFactory
+}
+
+// Override
+func (hkdfFactory) ParseKeyDerivationFunction(info StringKeyMap) KeyDerivationFunction {
+	return NewHKDFWithMap(info)
+}
+
+type pbkdf2Factory struct {
+	//KeyDerivationFunctionFactory
+}
+
+// Override
+func (pbkdf2Factory) ParseKeyDerivationFunction(info StringKeyMap) KeyDerivationFunction {
+	// check 'salt', 'iterations'
+	if !ContainsKey(info, "salt") || !ContainsKey(info, "iterations") {
+		return nil
+	}
+	return NewPBKDF2WithMap(info)
+}
+
+type scryptFactory struct {
+	//KeyDerivationFunctionFactory
+}
+
+// Override
+func (scryptFactory) ParseKeyDerivationFunction(info StringKeyMap) KeyDerivationFunction {
+	// check 'salt', 'N'
+	if !ContainsKey(info, "salt") || !ContainsKey(info, "N") {
+		return nil
+	}
+	return NewScryptWithMap(info)
+}
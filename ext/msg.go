@@ -30,6 +30,7 @@ import (
 	. "github.com/dimchat/dkd-go/protocol"
 	. "github.com/dimchat/mkm-go/protocol"
 	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/schema"
 )
 
 type IMessageGeneralFactory interface {
@@ -97,6 +98,11 @@ func (gf *MessageGeneralFactory) ParseContent(content any) Content {
 		//panic("content error")
 		return nil
 	}
+	// check schema in strict mode
+	if !CheckContent(info) {
+		//panic("content format error")
+		return nil
+	}
 	// get factory by content type
 	msgType := gf.GetContentType(info, "")
 	factory := gf.GetContentFactory(msgType)
@@ -143,6 +149,11 @@ func (gf *MessageGeneralFactory) ParseEnvelope(env any) Envelope {
 		//panic("envelope error")
 		return nil
 	}
+	// check schema in strict mode
+	if !CheckSchema(ENVELOPE_SCHEMA, info) {
+		//panic("envelope format error")
+		return nil
+	}
 	factory := gf.GetEnvelopeFactory()
 	return factory.ParseEnvelope(info)
 }
@@ -179,6 +190,11 @@ func (gf *MessageGeneralFactory) ParseInstantMessage(msg any) InstantMessage {
 		//panic("instant message error")
 		return nil
 	}
+	// check schema in strict mode
+	if !CheckSchema(INSTANT_SCHEMA, info) {
+		//panic("instant message format error")
+		return nil
+	}
 	factory := gf.GetInstantMessageFactory()
 	return factory.ParseInstantMessage(info)
 }
@@ -215,6 +231,11 @@ func (gf *MessageGeneralFactory) ParseSecureMessage(msg any) SecureMessage {
 		//panic("secure message error")
 		return nil
 	}
+	// check schema in strict mode
+	if !CheckSchema(SECURE_SCHEMA, info) {
+		//panic("secure message format error")
+		return nil
+	}
 	factory := gf.GetSecureMessageFactory()
 	return factory.ParseSecureMessage(info)
 }
@@ -245,6 +266,11 @@ func (gf *MessageGeneralFactory) ParseReliableMessage(msg any) ReliableMessage {
 		//panic("reliable message error")
 		return nil
 	}
+	// check schema in strict mode
+	if !CheckSchema(RELIABLE_SCHEMA, info) {
+		//panic("reliable message format error")
+		return nil
+	}
 	factory := gf.GetReliableMessageFactory()
 	return factory.ParseReliableMessage(info)
 }
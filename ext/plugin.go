@@ -26,13 +26,17 @@
 package ext
 
 import (
+	. "github.com/dimchat/core-go/format"
 	. "github.com/dimchat/core-go/protocol"
 	. "github.com/dimchat/mkm-go/crypto"
 	. "github.com/dimchat/mkm-go/digest"
 	. "github.com/dimchat/mkm-go/format"
 	. "github.com/dimchat/mkm-go/protocol"
+	. "github.com/dimchat/plugins-go/compress"
+	. "github.com/dimchat/plugins-go/crypto"
 	. "github.com/dimchat/plugins-go/digest"
 	. "github.com/dimchat/plugins-go/format"
+	. "github.com/dimchat/plugins-go/kdf"
 	. "github.com/dimchat/plugins-go/mkm"
 )
 
@@ -52,6 +56,8 @@ func (loader PluginLoader) Load() {
 
 	loader.RegisterCoders()
 	loader.RegisterDigesters()
+	loader.RegisterKeyDerivationFunctions()
+	loader.RegisterCompressionCodecs()
 
 	loader.RegisterSymmetricKeyFactories()
 	loader.RegisterAsymmetricKeyFactories()
@@ -76,10 +82,19 @@ func (loader PluginLoader) RegisterCoders() {
 
 	// JSON
 	SetJSONCoder(NewJSONCoder())
+	//SetJSONCoder(NewCanonicalJSONCoder())  // RFC 8785
+	//SetJSONCoder(NewLosslessJSONCoder())   // int64/uint64/json.Number
+	//SetConverter(NewNumberConverter())     // json.Number, for the lossless JSON coder
 
 	// UTF-8
 	SetUTF8Coder(NewUTF8Coder())
 
+	// Data URI encodings
+	SetDataCoder(BASE_64, NewLenientBase64Coder())
+	SetDataCoder(BASE_58, NewBase58Coder())
+	SetDataCoder(HEX, NewHexCoder())
+	SetDataCoder(URL_ESCAPED, NewPercentCoder())
+
 	// TED
 	SetTransportableDataFactory(NewTransportableDataFactory())
 	// PNF
@@ -95,13 +110,70 @@ func (loader PluginLoader) RegisterCoders() {
 func (loader PluginLoader) RegisterDigesters() {
 
 	// SHA-256
-	SetSHA256Digester(NewSHA256Digester())
+	sha256 := NewSHA256Digester()
+	SetSHA256Digester(sha256)
+	SetDigester("SHA-256", sha256)
+
+	// MD-5 & SHA-1 (compatibility only)
+	SetDigester("MD5", NewMD5Digester())
+	SetDigester("SHA-1", NewSHA1Digester())
+
+	// SHA-512
+	SetDigester("SHA-512", NewSHA512Digester())
+
+	// SHA-3
+	SetDigester("SHA3-256", NewSHA3256Digester())
+	SetDigester("SHA3-512", NewSHA3512Digester())
 
-	//// RipeMD-160
-	//SetRIPEMD160Digester(NewRIPEMD160Digester())
-	//
-	//// Keccak-256
-	//SetKECCAK256Digester(NewKECCAK256Digester())
+	// BLAKE2b
+	SetDigester("BLAKE2b-256", NewBLAKE2b256Digester())
+	SetDigester("BLAKE2b-512", NewBLAKE2b512Digester())
+
+	// RipeMD-160
+	ripemd160 := NewRIPEMD160Digester()
+	SetRIPEMD160Digester(ripemd160)
+	SetDigester("RIPEMD-160", ripemd160)
+
+	// Keccak-256
+	keccak256 := NewKECCAK256Digester()
+	SetKECCAK256Digester(keccak256)
+	SetDigester("KECCAK-256", keccak256)
+
+}
+
+/**
+ *  Key derivation functions
+ */
+
+// protected
+func (loader PluginLoader) RegisterKeyDerivationFunctions() {
+
+	// HKDF
+	SetKeyDerivationFunctionFactory(HKDF, &hkdfFactory{})
+
+	// PBKDF2
+	SetKeyDerivationFunctionFactory(PBKDF2, &pbkdf2Factory{})
+
+	// scrypt
+	SetKeyDerivationFunctionFactory(SCRYPT, &scryptFactory{})
+
+}
+
+/**
+ *  Compression codecs
+ */
+
+// protected
+func (loader PluginLoader) RegisterCompressionCodecs() {
+
+	// Deflate
+	SetCodec(DEFLATE, NewDeflateCodec())
+
+	// zlib
+	SetCodec(ZLIB, NewZlibCodec())
+
+	// zstd: not built-in, register it with a zstd library
+	//SetCodec(ZSTD, codec)
 
 }
 
@@ -118,6 +190,9 @@ func (loader PluginLoader) RegisterSymmetricKeyFactories() {
 	SetSymmetricKeyFactory(AES_CBC_PKCS7, factory)
 	//SetSymmetricKeyFactory("AES/CBC/PKCS7Padding", factory)
 
+	// AES-256 + HMAC-SHA256 (Encrypt-then-MAC)
+	SetSymmetricKeyFactory(AES_CBC_HMAC_SHA256, &aesHMACFactory{})
+
 	// Plain
 	SetSymmetricKeyFactory(PLAIN, &plainFactory{})
 
@@ -134,21 +209,29 @@ func (loader PluginLoader) RegisterAsymmetricKeyFactories() {
 	rsaPri := &rsaPrivateFactory{}
 	SetPrivateKeyFactory(RSA, rsaPri)
 	SetPrivateKeyFactory(RSA_SHA256, rsaPri)
+	SetPrivateKeyFactory(RSA_SHA512, rsaPri)
 	SetPrivateKeyFactory(RSA_ECB_PKCS1, rsaPri)
 
 	rsaPub := &rsaPublicFactory{}
 	SetPublicKeyFactory(RSA, rsaPub)
 	SetPublicKeyFactory(RSA_SHA256, rsaPub)
+	SetPublicKeyFactory(RSA_SHA512, rsaPub)
 	SetPublicKeyFactory(RSA_ECB_PKCS1, rsaPub)
 
 	// ECC
 	eccPri := &eccPrivateFactory{}
 	SetPrivateKeyFactory(ECC, eccPri)
 	SetPrivateKeyFactory(ECDSA_SHA256, eccPri)
+	SetPrivateKeyFactory(ECDSA_SHA512, eccPri)
+	SetPrivateKeyFactory(ECDSA_SHA3_256, eccPri)
+	SetPrivateKeyFactory(ECDSA_KECCAK256, eccPri)
 
 	eccPub := &eccPublicFactory{}
 	SetPublicKeyFactory(ECC, eccPub)
 	SetPublicKeyFactory(ECDSA_SHA256, eccPub)
+	SetPublicKeyFactory(ECDSA_SHA512, eccPub)
+	SetPublicKeyFactory(ECDSA_SHA3_256, eccPub)
+	SetPublicKeyFactory(ECDSA_KECCAK256, eccPub)
 
 }
 
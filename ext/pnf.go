@@ -47,7 +47,22 @@ func (pnfFactory) CreateTransportableFile(
 	data TransportableData, filename string,
 	url URL, password DecryptKey,
 ) TransportableFile {
-	return NewPortableNetworkFileWithData(data, filename, url, password)
+	// NOTICE: 'NewPortableNetworkFileWithData()' calls the wrapper before
+	//         it's created, so build it with an empty map here
+	pnf := NewPortableNetworkFileWithMap(NewMap())
+	if data != nil {
+		pnf.SetData(data)
+	}
+	if filename != "" {
+		pnf.SetFilename(filename)
+	}
+	if url != nil {
+		pnf.SetURL(url)
+	}
+	if password != nil {
+		pnf.SetPassword(password)
+	}
+	return pnf
 }
 
 // Override
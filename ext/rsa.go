@@ -35,6 +35,7 @@ import (
 //goland:noinspection GoSnakeCaseUsage
 const (
 	RSA_SHA256    = "SHA256withRSA"
+	RSA_SHA512    = "SHA512withRSA"
 	RSA_ECB_PKCS1 = "RSA/ECB/PKCS1Padding"
 )
 
@@ -31,6 +31,8 @@ import (
 	. "github.com/dimchat/core-go/format"
 	. "github.com/dimchat/core-go/rfc"
 	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/format"
 )
 
 func NewTransportableDataFactory() TransportableDataFactory {
@@ -46,15 +48,42 @@ func (tedFactory) ParseTransportableData(ted string) TransportableData {
 	uri := ParseDataURI(ted)
 	if uri != nil {
 		encoding := uri.Head().Encoding()
-		encoding = strings.ToLower(encoding)
-		if encoding == BASE_64 {
-			// "data:image/jpeg;base64,..."
-			return NewEmbedDataWithURI(uri)
+		if GetDataCoder(strings.ToLower(encoding)) == nil {
+			//panic("data encoding not supported: " + encoding)
+			return nil
+		} else if encoding != strings.ToLower(encoding) {
+			// rebuild the header for lower-case encoding name,
+			// as the data coder is selected by it
+			head := uri.Head()
+			extra := NewMap()
+			for _, key := range head.ExtraKeys() {
+				extra[key] = head.ExtraValue(key)
+			}
+			head = NewDataHeader(head.MimeType(), strings.ToLower(encoding), extra)
+			uri = NewDataURI(head, uri.Body())
+			// keep the original string
+			return NewEmbedData(ted, nil, uri, head)
 		}
-		// TODO: other encoding?
-		return nil
+		// "data:image/jpeg;base64,..."
+		// "data:application/octet-stream;hex,..."
+		// "data:text/plain;charset=utf-8,Hello%2C%20World!"
+		return NewEmbedDataWithURI(uri)
 	}
-	// TODO: check Base-64 format
 	// "{BASE64_ENCODED}"
-	return NewBase64DataWithString(ted)
+	coder := GuessBase64Coder(ted)
+	if coder == nil {
+		//panic("Base-64 format error")
+		return nil
+	} else if std, ok := coder.(*Base64Coder); ok && std.IsStandard() {
+		// standard Base-64, decode it lazily
+		return NewBase64DataWithString(ted)
+	}
+	// URL-safe, unpadded or wrapped, decode it now
+	// with the matched coder, and keep the original string
+	data := coder.Decode(ted)
+	if data == nil {
+		//panic("Base-64 data error")
+		return nil
+	}
+	return NewBase64Data(ted, data)
 }
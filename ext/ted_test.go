@@ -0,0 +1,155 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package ext
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/plugins-go/format"
+)
+
+func init() {
+	ExtensionLoader{}.Load()
+	PluginLoader{}.Load()
+}
+
+// tedTestData contains all byte values, so that '+', '/' (or '-', '_') appear in Base64
+func tedTestData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	return data
+}
+
+func TestParseBase64Variants(t *testing.T) {
+	data := tedTestData(1000)
+	std := base64.StdEncoding.EncodeToString(data)
+	texts := map[string]string{
+		"std":     std,
+		"raw std": base64.RawStdEncoding.EncodeToString(data),
+		"url":     base64.URLEncoding.EncodeToString(data),
+		"raw url": base64.RawURLEncoding.EncodeToString(data),
+		"wrapped": std[:76] + "\r\n" + std[76:152] + "\n " + std[152:],
+	}
+	for name, text := range texts {
+		ted := ParseTransportableData(text)
+		if ted == nil || !bytes.Equal(ted.Bytes(), data) {
+			t.Errorf("%s: failed to parse TED", name)
+			continue
+		}
+		// the original string is kept
+		if ted.Serialize() != text {
+			t.Errorf("%s: serialized = %s", name, ted.Serialize())
+		}
+	}
+}
+
+func TestParseBase64Invalid(t *testing.T) {
+	texts := []string{
+		"ab+/cd-_", // mixed alphabets
+		"YWJj*",    // invalid character
+		"YWJjZ",    // truncated
+	}
+	for _, text := range texts {
+		if ted := ParseTransportableData(text); ted != nil && ted.Bytes() != nil {
+			t.Errorf("parsed invalid TED: %q", text)
+		}
+	}
+}
+
+func TestParseBase64DataURI(t *testing.T) {
+	// large data URI with both '+' and '/', in standard & URL-safe alphabets
+	data := bytes.Repeat([]byte{0xFB, 0xFF, 0xBF}, 1<<18) // "+/+/"
+	std := base64.StdEncoding.EncodeToString(data)
+	uris := []string{
+		"data:image/png;base64," + std,
+		"data:image/png;base64," + base64.RawURLEncoding.EncodeToString(data),
+		"data:image/png;base64," + strings.Join(strings.SplitAfter(std, "+/+/+/+/"), "\n"),
+	}
+	for index, uri := range uris {
+		ted := ParseTransportableData(uri)
+		if ted == nil || !bytes.Equal(ted.Bytes(), data) {
+			t.Errorf("#%d: failed to decode data URI", index)
+		}
+	}
+}
+
+func TestParseDataURIEncodings(t *testing.T) {
+	data := tedTestData(300)
+	uris := map[string]string{
+		"base64": "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(data),
+		"BASE64": "data:application/octet-stream;BASE64," + base64.StdEncoding.EncodeToString(data),
+		"base58": "data:application/octet-stream;base58," + Base58Encode(data),
+		"hex":    "data:application/octet-stream;hex," + HexEncode(data),
+		"0x":     "data:application/octet-stream;hex,0x" + HexEncode(data),
+	}
+	for name, uri := range uris {
+		ted := ParseTransportableData(uri)
+		if ted == nil || !bytes.Equal(ted.Bytes(), data) {
+			t.Errorf("%s: failed to decode data URI", name)
+		} else if ted.Serialize() != uri {
+			t.Errorf("%s: serialized = %s", name, ted.Serialize())
+		}
+	}
+	// RFC 2397 URL escaped
+	ted := ParseTransportableData("data:text/plain;charset=utf-8,Hello%2C%20World!")
+	if ted == nil || string(ted.Bytes()) != "Hello, World!" {
+		t.Errorf("failed to decode URL escaped data URI")
+	}
+	// unknown encoding, or broken data
+	for _, uri := range []string{
+		"data:application/octet-stream;base32,MFRGG===",
+		"data:application/octet-stream;hex,0x0",
+		"data:application/octet-stream;base58,0OIl",
+		"data:text/plain,100%",
+	} {
+		if ted = ParseTransportableData(uri); ted != nil && ted.Bytes() != nil {
+			t.Errorf("decoded invalid data URI: %s", uri)
+		}
+	}
+}
+
+func TestEmbedDataWithEncoding(t *testing.T) {
+	data := tedTestData(100)
+	for _, encoding := range []string{"base64", "base58", "hex", ""} {
+		ted := NewEmbedDataWithEncoding("application/octet-stream", encoding, data)
+		if ted == nil {
+			t.Fatalf("%q: encoding not supported", encoding)
+		}
+		other := ParseTransportableData(ted.Serialize())
+		if other == nil || !bytes.Equal(other.Bytes(), data) {
+			t.Errorf("%q: round trip failed: %s", encoding, ted.Serialize())
+		}
+	}
+	if NewEmbedDataWithEncoding("application/octet-stream", "base32", data) != nil {
+		t.Errorf("unknown encoding accepted")
+	}
+}
@@ -26,8 +26,7 @@
 package format
 
 import (
-	"bytes"
-	"math/big"
+	"errors"
 
 	. "github.com/dimchat/mkm-go/format"
 )
@@ -36,45 +35,113 @@ func NewBase58Coder() DataCoder {
 	return &Base58Coder{}
 }
 
+// Base58Coder implements Base58 with the Bitcoin alphabet,
+// each leading zero byte is encoded as a leading '1'
 type Base58Coder struct {
 	//DataCoder
 }
 
 // Override
 func (Base58Coder) Encode(data []byte) string {
-	x := big.NewInt(0).SetBytes(data)
-	base := big.NewInt(58)
-	zero := big.NewInt(0)
-	mod := &big.Int{}
-	var result []byte
-	for x.Cmp(zero) != 0 {
-		x.DivMod(x, base, mod)
-		result = append(result, base58Alphabets[mod.Int64()])
-	}
-	reverseBytes(result)
-	return string(result)
+	return EncodeBase58(data)
 }
 
 // Override
 func (Base58Coder) Decode(string string) []byte {
-	result := big.NewInt(0)
-	input := []byte(string)
-	for _, b := range input {
-		charIndex := bytes.IndexByte(base58Alphabets, b)
-		result.Mul(result, big.NewInt(58))
-		result.Add(result, big.NewInt(int64(charIndex)))
-	}
-	decoded := result.Bytes()
-	if input[0] == base58Alphabets[0] {
-		decoded = append([]byte{0x00}, decoded...)
+	data, err := DecodeBase58(string)
+	if err != nil {
+		//panic(err)
+		return nil
 	}
-	return decoded
+	return data
 }
 
+var ErrBase58Character = errors.New("base58: invalid character")
+
 var base58Alphabets = []byte("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
 
-func reverseBytes(data []byte) {
-	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
-		data[i], data[j] = data[j], data[i]
+// base58Indexes maps characters to digit values, -1 for invalid characters
+var base58Indexes = func() [256]int8 {
+	var indexes [256]int8
+	for i := range indexes {
+		indexes[i] = -1
+	}
+	for i, ch := range base58Alphabets {
+		indexes[ch] = int8(i)
+	}
+	return indexes
+}()
+
+// EncodeBase58 encodes data to Base58 string (Bitcoin compatible)
+func EncodeBase58(data []byte) string {
+	// leading zeros
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+	// base256 to base58, big-endian: log(256) / log(58) < 1.37
+	size := (len(data)-zeros)*137/100 + 1
+	digits := make([]byte, size)
+	length := 0
+	for _, b := range data[zeros:] {
+		carry := int(b)
+		i := 0
+		for j := size - 1; (carry != 0 || i < length) && j >= 0; j-- {
+			carry += 256 * int(digits[j])
+			digits[j] = byte(carry % 58)
+			carry /= 58
+			i++
+		}
+		length = i
+	}
+	// skip leading zero digits
+	start := size - length
+	for start < size && digits[start] == 0 {
+		start++
+	}
+	result := make([]byte, zeros+size-start)
+	for i := 0; i < zeros; i++ {
+		result[i] = base58Alphabets[0]
+	}
+	for i, d := range digits[start:] {
+		result[zeros+i] = base58Alphabets[d]
+	}
+	return string(result)
+}
+
+// DecodeBase58 decodes Base58 string (Bitcoin compatible)
+//
+// Returns: error on invalid character
+func DecodeBase58(text string) ([]byte, error) {
+	// leading '1's
+	zeros := 0
+	for zeros < len(text) && text[zeros] == base58Alphabets[0] {
+		zeros++
+	}
+	// base58 to base256, big-endian: log(58) / log(256) < 0.733
+	size := (len(text)-zeros)*733/1000 + 1
+	bin := make([]byte, size)
+	length := 0
+	for k := zeros; k < len(text); k++ {
+		carry := int(base58Indexes[text[k]])
+		if carry < 0 {
+			return nil, ErrBase58Character
+		}
+		i := 0
+		for j := size - 1; (carry != 0 || i < length) && j >= 0; j-- {
+			carry += 58 * int(bin[j])
+			bin[j] = byte(carry % 256)
+			carry /= 256
+			i++
+		}
+		length = i
+	}
+	// skip leading zero bytes of the number
+	start := size - length
+	for start < size && bin[start] == 0 {
+		start++
 	}
+	result := make([]byte, zeros+size-start)
+	copy(result[zeros:], bin[start:])
+	return result, nil
 }
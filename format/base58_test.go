@@ -0,0 +1,84 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// Bitcoin Core: src/test/data/base58_encode_decode.json
+var base58Vectors = [][2]string{
+	{"", ""},
+	{"61", "2g"},
+	{"626262", "a3gV"},
+	{"636363", "aPEr"},
+	{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+	{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+	{"516b6fcd0f", "ABnLTmg"},
+	{"bf4f89001e670274dd", "3SEo3LWLoPntC"},
+	{"572e4794", "3EFU7m"},
+	{"ecac89cad93923c02321", "EJDM8drfXA6uyA"},
+	{"10c8511e", "Rt5zm"},
+	{"00000000000000000000", "1111111111"},
+	{"000111d38e5fc9071ffcd20b4a763cc9ae4f252bb4e48fd66a835e252ada93ff480d6dd43dc62a641155a5", "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"},
+}
+
+func TestBase58(t *testing.T) {
+	for _, v := range base58Vectors {
+		data, _ := hex.DecodeString(v[0])
+		if out := EncodeBase58(data); out != v[1] {
+			t.Errorf("EncodeBase58(%s) = %s, want %s", v[0], out, v[1])
+		}
+		out, err := DecodeBase58(v[1])
+		if err != nil || !bytes.Equal(out, data) {
+			t.Errorf("DecodeBase58(%s) = %x, %v, want %s", v[1], out, err, v[0])
+		}
+	}
+}
+
+func TestBase58LeadingZeros(t *testing.T) {
+	for zeros := 0; zeros < 4; zeros++ {
+		data := append(make([]byte, zeros), 0x00, 0x01, 0xFF)
+		text := NewBase58Coder().Encode(data)
+		if out := NewBase58Coder().Decode(text); !bytes.Equal(out, data) {
+			t.Errorf("round trip %x: %s -> %x", data, text, out)
+		}
+	}
+}
+
+func TestBase58Invalid(t *testing.T) {
+	for _, text := range []string{"0", "O", "I", "l", " ", "3mJr7AoUXx2Wqd ", "1\x00", "3SEo3LWLoPnt\xe9"} {
+		if _, err := DecodeBase58(text); !errors.Is(err, ErrBase58Character) {
+			t.Errorf("DecodeBase58(%q) error = %v", text, err)
+		}
+		if NewBase58Coder().Decode(text) != nil {
+			t.Errorf("Decode(%q) not nil", text)
+		}
+	}
+}
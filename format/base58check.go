@@ -0,0 +1,69 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+)
+
+var ErrBase58Checksum = errors.New("base58check: checksum mismatch")
+
+// EncodeBase58Check encodes the version byte & payload with checksum
+//
+//	base58(version | payload | SHA256(SHA256(version | payload))[:4])
+func EncodeBase58Check(version byte, payload []byte) string {
+	data := make([]byte, 0, 1+len(payload)+4)
+	data = append(data, version)
+	data = append(data, payload...)
+	data = append(data, base58Checksum(data)...)
+	return EncodeBase58(data)
+}
+
+// DecodeBase58Check decodes the Base58Check string
+//
+// Returns: version byte & payload, error on invalid character or checksum
+func DecodeBase58Check(text string) (byte, []byte, error) {
+	data, err := DecodeBase58(text)
+	if err != nil {
+		return 0, nil, err
+	} else if len(data) < 5 {
+		return 0, nil, ErrBase58Checksum
+	}
+	body := data[:len(data)-4]
+	cc := data[len(data)-4:]
+	if subtle.ConstantTimeCompare(cc, base58Checksum(body)) != 1 {
+		return 0, nil, ErrBase58Checksum
+	}
+	return body[0], body[1:], nil
+}
+
+func base58Checksum(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
@@ -0,0 +1,70 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestBase58Check(t *testing.T) {
+	vectors := []struct {
+		version byte
+		payload string
+		text    string
+	}{
+		// P2PKH address (Bitcoin wiki: Technical background of version 1 Bitcoin addresses)
+		{0x00, "f54a5851e9372b87810a8e60cdd2e7cfd80b6e31", "1PMycacnJaSqwwJqjawXBErnLsZ7RkXUAs"},
+		// WIF private key (Bitcoin wiki: Wallet import format)
+		{0x80, "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d", "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"},
+	}
+	for _, v := range vectors {
+		payload, _ := hex.DecodeString(v.payload)
+		if out := EncodeBase58Check(v.version, payload); out != v.text {
+			t.Errorf("EncodeBase58Check(%s) = %s, want %s", v.payload, out, v.text)
+		}
+		version, out, err := DecodeBase58Check(v.text)
+		if err != nil || version != v.version || !bytes.Equal(out, payload) {
+			t.Errorf("DecodeBase58Check(%s) = %d, %x, %v", v.text, version, out, err)
+		}
+	}
+}
+
+func TestBase58CheckInvalid(t *testing.T) {
+	// last character changed
+	if _, _, err := DecodeBase58Check("1PMycacnJaSqwwJqjawXBErnLsZ7RkXUAt"); !errors.Is(err, ErrBase58Checksum) {
+		t.Errorf("bad checksum error = %v", err)
+	}
+	// too short for version & checksum
+	if _, _, err := DecodeBase58Check("1111"); !errors.Is(err, ErrBase58Checksum) {
+		t.Errorf("short data error = %v", err)
+	}
+	if _, _, err := DecodeBase58Check("1PMycacnJaSqwwJqjawXBErnLsZ7RkXUA0"); !errors.Is(err, ErrBase58Character) {
+		t.Errorf("bad character error = %v", err)
+	}
+}
@@ -27,6 +27,7 @@ package format
 
 import (
 	"encoding/base64"
+	"strings"
 
 	. "github.com/dimchat/mkm-go/format"
 )
@@ -35,20 +36,156 @@ func NewBase64Coder() DataCoder {
 	return &Base64Coder{}
 }
 
+// NewRawStdBase64Coder creates coder with standard alphabet, without padding
+func NewRawStdBase64Coder() DataCoder {
+	return &Base64Coder{encoding: base64.RawStdEncoding}
+}
+
+// NewURLBase64Coder creates coder with URL-safe alphabet ('-', '_'), with padding
+func NewURLBase64Coder() DataCoder {
+	return &Base64Coder{encoding: base64.URLEncoding}
+}
+
+// NewRawURLBase64Coder creates coder with URL-safe alphabet, without padding
+func NewRawURLBase64Coder() DataCoder {
+	return &Base64Coder{encoding: base64.RawURLEncoding}
+}
+
+// Base64Coder encodes/decodes with one strict Base64 variant,
+// the zero value uses the standard alphabet with padding
 type Base64Coder struct {
 	//DataCoder
+
+	encoding *base64.Encoding
+}
+
+func (coder Base64Coder) getEncoding() *base64.Encoding {
+	if coder.encoding == nil {
+		return base64.StdEncoding
+	}
+	return coder.encoding
+}
+
+// IsStandard returns true for the standard alphabet with padding (RFC 4648 §4)
+func (coder Base64Coder) IsStandard() bool {
+	return coder.getEncoding() == base64.StdEncoding
+}
+
+// Override
+func (coder Base64Coder) Encode(data []byte) string {
+	return coder.getEncoding().EncodeToString(data)
+}
+
+// Override
+func (coder Base64Coder) Decode(string string) []byte {
+	bytes, err := coder.getEncoding().DecodeString(string)
+	if err != nil {
+		//panic(err)
+		return nil
+	}
+	return bytes
+}
+
+func NewLenientBase64Coder() DataCoder {
+	return &LenientBase64Coder{}
+}
+
+// LenientBase64Coder encodes as standard Base64, and decodes any variant:
+// standard or URL-safe alphabet, with or without padding,
+// and wrapped with whitespaces (MIME/PEM style)
+type LenientBase64Coder struct {
+	//DataCoder
 }
 
 // Override
-func (Base64Coder) Encode(data []byte) string {
+func (LenientBase64Coder) Encode(data []byte) string {
 	return base64.StdEncoding.EncodeToString(data)
 }
 
 // Override
-func (Base64Coder) Decode(string string) []byte {
-	bytes, err := base64.StdEncoding.DecodeString(string)
+func (LenientBase64Coder) Decode(string string) []byte {
+	bytes, err := DecodeBase64Lenient(string)
 	if err != nil {
-		panic(err)
+		//panic(err)
+		return nil
 	}
 	return bytes
 }
+
+// DecodeBase64Lenient decodes Base64 string in any variant,
+// whitespaces and padding are ignored; mixing the two alphabets is an error
+func DecodeBase64Lenient(text string) ([]byte, error) {
+	// decide the alphabet before mapping characters
+	if strings.ContainsAny(text, "-_") {
+		if pos := strings.IndexAny(text, "+/"); pos >= 0 {
+			// mixed alphabets
+			return nil, base64.CorruptInputError(pos)
+		}
+	}
+	var sb strings.Builder
+	sb.Grow(len(text))
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch ch {
+		case ' ', '\t', '\r', '\n', '=':
+			// skip
+			continue
+		case '-':
+			ch = '+'
+		case '_':
+			ch = '/'
+		}
+		sb.WriteByte(ch)
+	}
+	return base64.RawStdEncoding.DecodeString(sb.String())
+}
+
+// GuessBase64Coder selects the coder for the Base64 variant of this text:
+// the strict one when the text is clean, the lenient one when it contains
+// whitespaces or misplaced padding
+//
+// Returns: nil when the text is not Base64 at all
+func GuessBase64Coder(text string) DataCoder {
+	var url, std, pad, space bool
+	count := 0 // Base64 characters
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9':
+			count++
+		case ch == '+' || ch == '/':
+			std = true
+			count++
+		case ch == '-' || ch == '_':
+			url = true
+			count++
+		case ch == '=':
+			pad = true
+		case ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n':
+			space = true
+		default:
+			// invalid character
+			return nil
+		}
+	}
+	if std && url {
+		// mixed alphabets
+		return nil
+	} else if count%4 == 1 {
+		// truncated
+		return nil
+	} else if space {
+		return NewLenientBase64Coder()
+	} else if pad && (len(text)%4 != 0 || len(text)-count > 2 || strings.TrimRight(text, "=") != text[:count]) {
+		// padding error
+		return NewLenientBase64Coder()
+	} else if url {
+		if pad || count%4 == 0 {
+			return NewURLBase64Coder()
+		}
+		return NewRawURLBase64Coder()
+	} else if pad || count%4 == 0 {
+		return NewBase64Coder()
+	}
+	return NewRawStdBase64Coder()
+}
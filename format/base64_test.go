@@ -0,0 +1,145 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	. "github.com/dimchat/mkm-go/format"
+)
+
+// all bytes, so that '+', '/' (or '-', '_') appear in the encoded string
+func base64TestData() []byte {
+	data := make([]byte, 256*3)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return data
+}
+
+func TestDecodeBase64Lenient(t *testing.T) {
+	data := base64TestData()
+	std := base64.StdEncoding.EncodeToString(data)
+	// wrapped at 76 characters (MIME)
+	var mime strings.Builder
+	for i := 0; i < len(std); i += 76 {
+		end := i + 76
+		if end > len(std) {
+			end = len(std)
+		}
+		mime.WriteString(std[i:end])
+		mime.WriteString("\r\n")
+	}
+	texts := map[string]string{
+		"std":     std,
+		"raw std": base64.RawStdEncoding.EncodeToString(data),
+		"url":     base64.URLEncoding.EncodeToString(data),
+		"raw url": base64.RawURLEncoding.EncodeToString(data),
+		"mime":    mime.String(),
+		"spaces":  " " + strings.Join(strings.SplitAfter(std, "A"), "\t ") + "\n",
+	}
+	for name, text := range texts {
+		out, err := DecodeBase64Lenient(text)
+		if err != nil || !bytes.Equal(out, data) {
+			t.Errorf("%s: DecodeBase64Lenient() error = %v", name, err)
+		}
+		if out = NewLenientBase64Coder().Decode(text); !bytes.Equal(out, data) {
+			t.Errorf("%s: LenientBase64Coder.Decode() failed", name)
+		}
+	}
+	// missing padding
+	for text, expected := range map[string]string{"YQ": "a", "YWI": "ab", "YWJj": "abc", "YQ=": "a", "": ""} {
+		out, err := DecodeBase64Lenient(text)
+		if err != nil || string(out) != expected {
+			t.Errorf("DecodeBase64Lenient(%q) = %q, %v", text, out, err)
+		}
+	}
+}
+
+func TestDecodeBase64LenientInvalid(t *testing.T) {
+	texts := []string{
+		"ab+/cd-_", // mixed alphabets
+		"a_b/",     // mixed alphabets
+		"YWJj*",    // invalid character
+		"YWJjZ",    // truncated
+		"YW\x00Jj", // invalid character
+	}
+	for _, text := range texts {
+		if _, err := DecodeBase64Lenient(text); err == nil {
+			t.Errorf("DecodeBase64Lenient(%q) accepted", text)
+		}
+		if NewLenientBase64Coder().Decode(text) != nil {
+			t.Errorf("LenientBase64Coder.Decode(%q) not nil", text)
+		}
+	}
+	// large input with both alphabets mixed at the end
+	text := strings.Repeat("+/+/", 1<<18) + "-_"
+	if _, err := DecodeBase64Lenient(text); err == nil {
+		t.Errorf("DecodeBase64Lenient(large mixed) accepted")
+	}
+}
+
+func TestBase64Coders(t *testing.T) {
+	data := base64TestData()[:100] // 100 % 3 == 1, padding '=='
+	coders := map[string]struct {
+		coder    DataCoder
+		encoding *base64.Encoding
+	}{
+		"std":     {NewBase64Coder(), base64.StdEncoding},
+		"raw std": {NewRawStdBase64Coder(), base64.RawStdEncoding},
+		"url":     {NewURLBase64Coder(), base64.URLEncoding},
+		"raw url": {NewRawURLBase64Coder(), base64.RawURLEncoding},
+	}
+	for name, c := range coders {
+		text := c.coder.Encode(data)
+		if text != c.encoding.EncodeToString(data) {
+			t.Errorf("%s: Encode() = %s", name, text)
+		}
+		if out := c.coder.Decode(text); !bytes.Equal(out, data) {
+			t.Errorf("%s: Decode() failed", name)
+		}
+		// strict coders reject other variants
+		if c.coder.Decode(text+"*") != nil || c.coder.Decode(" "+text) != nil {
+			t.Errorf("%s: Decode() accepted invalid string", name)
+		}
+		// the guessed coder is the same variant
+		guess, ok := GuessBase64Coder(text).(*Base64Coder)
+		if !ok || guess.Encode(data) != text {
+			t.Errorf("%s: GuessBase64Coder() = %v", name, guess)
+		}
+	}
+	if _, ok := GuessBase64Coder("YWJj\nZGVm").(*LenientBase64Coder); !ok {
+		t.Errorf("GuessBase64Coder(wrapped) not lenient")
+	}
+	for _, text := range []string{"ab+/cd-_", "YWJj*", "YWJjZ", "hello, world"} {
+		if GuessBase64Coder(text) != nil {
+			t.Errorf("GuessBase64Coder(%q) not nil", text)
+		}
+	}
+}
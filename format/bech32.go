@@ -0,0 +1,250 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"errors"
+	"strings"
+)
+
+// Bech32Spec is the checksum variant of Bech32 strings
+type Bech32Spec int
+
+const (
+	BECH32  Bech32Spec = 1 // BIP-173
+	BECH32M Bech32Spec = 2 // BIP-350
+)
+
+var (
+	ErrBech32Format   = errors.New("bech32: invalid format")
+	ErrBech32Checksum = errors.New("bech32: checksum mismatch")
+	ErrBech32Bits     = errors.New("bech32: invalid data bits")
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var bech32Constants = map[Bech32Spec]uint32{
+	BECH32:  1,
+	BECH32M: 0x2bc830a3,
+}
+
+// EncodeBech32 encodes the human-readable part & 5-bit values
+//
+// Use ConvertBits(data, 8, 5, true) to get the 5-bit values from bytes
+func EncodeBech32(hrp string, values []byte, spec Bech32Spec) (string, error) {
+	constant, ok := bech32Constants[spec]
+	if !ok || !bech32CheckHRP(hrp) || len(hrp)+1+len(values)+6 > 90 {
+		return "", ErrBech32Format
+	}
+	if lower := strings.ToLower(hrp); lower != hrp && strings.ToUpper(hrp) != hrp {
+		// mixed case
+		return "", ErrBech32Format
+	} else {
+		hrp = lower
+	}
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range values {
+		if v >= 32 {
+			return "", ErrBech32Bits
+		}
+		sb.WriteByte(bech32Charset[v])
+	}
+	checksum := bech32Checksum(hrp, values, constant)
+	for _, v := range checksum {
+		sb.WriteByte(bech32Charset[v])
+	}
+	return sb.String(), nil
+}
+
+// DecodeBech32 decodes the Bech32 / Bech32m string
+//
+// Returns: human-readable part (lower case), 5-bit values and the checksum variant
+func DecodeBech32(text string) (string, []byte, Bech32Spec, error) {
+	if len(text) > 90 {
+		return "", nil, 0, ErrBech32Format
+	}
+	lower := strings.ToLower(text)
+	if lower != text && strings.ToUpper(text) != text {
+		// mixed case
+		return "", nil, 0, ErrBech32Format
+	}
+	pos := strings.LastIndexByte(lower, '1')
+	if pos < 1 || pos+7 > len(lower) || !bech32CheckHRP(lower[:pos]) {
+		return "", nil, 0, ErrBech32Format
+	}
+	hrp := lower[:pos]
+	values := make([]byte, 0, len(lower)-pos-1)
+	for i := pos + 1; i < len(lower); i++ {
+		v := strings.IndexByte(bech32Charset, lower[i])
+		if v < 0 {
+			return "", nil, 0, ErrBech32Format
+		}
+		values = append(values, byte(v))
+	}
+	polymod := bech32Polymod(append(bech32ExpandHRP(hrp), values...))
+	for spec, constant := range bech32Constants {
+		if polymod == constant {
+			return hrp, values[:len(values)-6], spec, nil
+		}
+	}
+	return "", nil, 0, ErrBech32Checksum
+}
+
+// ConvertBits regroups the values from 'from' bits to 'to' bits,
+// with 'pad' the incomplete group will be padded with zeros
+func ConvertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxValue := uint32(1)<<to - 1
+	result := make([]byte, 0, len(data)*int(from)/int(to)+1)
+	for _, value := range data {
+		if uint32(value)>>from != 0 {
+			return nil, ErrBech32Bits
+		}
+		acc = acc<<from | uint32(value)
+		bits += from
+		for bits >= to {
+			bits -= to
+			result = append(result, byte(acc>>bits&maxValue))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(to-bits)&maxValue))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxValue != 0 {
+		return nil, ErrBech32Bits
+	}
+	return result, nil
+}
+
+//
+//  SegWit address (BIP-173 / BIP-350)
+//
+
+// EncodeSegWitAddress encodes witness version (0 ~ 16) & program,
+// version 0 uses Bech32, others use Bech32m
+func EncodeSegWitAddress(hrp string, version byte, program []byte) (string, error) {
+	if !segWitCheck(version, program) {
+		return "", ErrBech32Format
+	}
+	values, err := ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	spec := BECH32M
+	if version == 0 {
+		spec = BECH32
+	}
+	return EncodeBech32(hrp, append([]byte{version}, values...), spec)
+}
+
+// DecodeSegWitAddress decodes the address with the expected human-readable part
+//
+// Returns: witness version & program
+func DecodeSegWitAddress(hrp string, address string) (byte, []byte, error) {
+	prefix, values, spec, err := DecodeBech32(address)
+	if err != nil {
+		return 0, nil, err
+	} else if prefix != strings.ToLower(hrp) || len(values) < 1 {
+		return 0, nil, ErrBech32Format
+	}
+	version := values[0]
+	if (version == 0 && spec != BECH32) || (version != 0 && spec != BECH32M) {
+		return 0, nil, ErrBech32Checksum
+	}
+	program, err := ConvertBits(values[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	} else if !segWitCheck(version, program) {
+		return 0, nil, ErrBech32Format
+	}
+	return version, program, nil
+}
+
+func segWitCheck(version byte, program []byte) bool {
+	if version > 16 || len(program) < 2 || len(program) > 40 {
+		return false
+	}
+	// version 0: P2WPKH (20 bytes) or P2WSH (32 bytes)
+	return version != 0 || len(program) == 20 || len(program) == 32
+}
+
+//
+//  Checksum
+//
+
+func bech32CheckHRP(hrp string) bool {
+	if len(hrp) < 1 || len(hrp) > 83 {
+		return false
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32ExpandHRP(hrp string) []byte {
+	result := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]>>5)
+	}
+	result = append(result, 0)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]&31)
+	}
+	return result
+}
+
+func bech32Checksum(hrp string, values []byte, constant uint32) []byte {
+	input := append(bech32ExpandHRP(hrp), values...)
+	input = append(input, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(input) ^ constant
+	checksum := make([]byte, 6)
+	for i := 0; i < 6; i++ {
+		checksum[i] = byte(polymod>>(5*(5-i))) & 31
+	}
+	return checksum
+}
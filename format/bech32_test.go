@@ -0,0 +1,135 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// BIP-173 & BIP-350 test vectors
+func TestBech32Valid(t *testing.T) {
+	vectors := map[Bech32Spec][]string{
+		BECH32: {
+			"A12UEL5L",
+			"a12uel5l",
+			"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+			"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+			"11" + strings.Repeat("q", 82) + "c8247j",
+			"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+			"?1ezyfcl",
+		},
+		BECH32M: {
+			"A1LQFN3A",
+			"a1lqfn3a",
+			"an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11sg7hg6",
+			"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+			"11" + strings.Repeat("l", 83) + "udsr8",
+			"split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+			"?1v759aa",
+		},
+	}
+	for spec, texts := range vectors {
+		for _, text := range texts {
+			hrp, values, out, err := DecodeBech32(text)
+			if err != nil || out != spec {
+				t.Errorf("DecodeBech32(%s) = %d, %v", text, out, err)
+				continue
+			}
+			// encode again
+			encoded, err := EncodeBech32(hrp, values, spec)
+			if err != nil || encoded != strings.ToLower(text) {
+				t.Errorf("EncodeBech32(%s) = %s, %v", text, encoded, err)
+			}
+		}
+	}
+}
+
+func TestBech32Invalid(t *testing.T) {
+	texts := []string{
+		"\x201nwldj5", // HRP character out of range
+		"\x7f1axkwrx", // HRP character out of range
+		"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", // overall max length exceeded
+		"pzry9x0s0muk",  // no separator character
+		"1pzry9x0s0muk", // empty HRP
+		"x1b4n0q5v",     // invalid data character
+		"li1dgmt3",      // too short checksum
+		"de1lg7wt\xff",  // invalid character in checksum
+		"A1G7SGD8",      // checksum calculated with uppercase form of HRP
+		"10a06t8",       // empty HRP
+		"1qzzfhee",      // empty HRP
+		"a12UEL5L",      // mixed case
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx", // checksum changed
+	}
+	for _, text := range texts {
+		if _, _, _, err := DecodeBech32(text); err == nil {
+			t.Errorf("DecodeBech32(%q) accepted", text)
+		}
+	}
+	if _, _, _, err := DecodeBech32("abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx"); !errors.Is(err, ErrBech32Checksum) {
+		t.Errorf("bad checksum error = %v", err)
+	}
+}
+
+func TestSegWitAddress(t *testing.T) {
+	vectors := []struct {
+		address string
+		version byte
+		program string
+	}{
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", 0, "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", 1,
+			"751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"BC1SW50QGDZ25J", 16, "751e"},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", 2, "751e76e8199196d454941c45d1b3a323"},
+	}
+	for _, v := range vectors {
+		program, _ := hex.DecodeString(v.program)
+		version, out, err := DecodeSegWitAddress("bc", v.address)
+		if err != nil || version != v.version || !bytes.Equal(out, program) {
+			t.Errorf("DecodeSegWitAddress(%s) = %d, %x, %v", v.address, version, out, err)
+		}
+		address, err := EncodeSegWitAddress("bc", v.version, program)
+		if err != nil || address != strings.ToLower(v.address) {
+			t.Errorf("EncodeSegWitAddress(%s) = %s, %v", v.program, address, err)
+		}
+	}
+	invalid := []string{
+		"tc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq5zuyut", // invalid HRP
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh",                     // Bech32m for v0
+		"bc1p38j9r5y49hruaue7wxjce0updqjuyyx0kh56v8s25huc6995vvpql3jow4", // invalid character
+		"bc1rw5uspcuh",                          // invalid program length
+		"bc1zw508d6qejxtdg4y5r3zarvaryvqyzf3du", // Bech32 for v1+
+	}
+	for _, address := range invalid {
+		if _, _, err := DecodeSegWitAddress("bc", address); err == nil {
+			t.Errorf("DecodeSegWitAddress(%s) accepted", address)
+		}
+	}
+}
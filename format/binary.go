@@ -0,0 +1,194 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+
+	. "github.com/dimchat/mkm-go/types"
+)
+
+// DefaultBinaryFields are the fields carrying Base64 encoded binary data
+// in messages and keys, the binary coders can send them as raw bytes
+var DefaultBinaryFields = []string{"data", "signature", "key"}
+
+var (
+	ErrBinaryFormat = errors.New("binary coder: format error")
+	ErrBinaryType   = errors.New("binary coder: unsupported value type")
+)
+
+// maxBinaryDepth limits the nesting level while decoding
+const maxBinaryDepth = 512
+
+// binaryOptions holds the options shared by CBOR and MessagePack coders
+type binaryOptions struct {
+
+	// deterministic sorts map keys by their encoded bytes,
+	// so the same object always gets the same encoding (for signing)
+	deterministic bool
+
+	// binaryFields are encoded as byte strings when the value is Base64,
+	// and decoded back to the same Base64 string
+	binaryFields map[string]bool
+}
+
+func newBinaryOptions(deterministic bool, binaryFields []string) binaryOptions {
+	var fields map[string]bool
+	if len(binaryFields) > 0 {
+		fields = make(map[string]bool, len(binaryFields))
+		for _, name := range binaryFields {
+			fields[name] = true
+		}
+	}
+	return binaryOptions{
+		deterministic: deterministic,
+		binaryFields:  fields,
+	}
+}
+
+// binaryField returns the bytes when the field is a binary field,
+// and its value is a standard Base64 string (which can be restored exactly)
+func (opts binaryOptions) binaryField(key string, value any) ([]byte, bool) {
+	if !opts.binaryFields[key] {
+		return nil, false
+	}
+	str, ok := value.(string)
+	if !ok || len(str) == 0 {
+		return nil, false
+	}
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil || base64.StdEncoding.EncodeToString(data) != str {
+		return nil, false
+	}
+	return data, true
+}
+
+// sortEntries sorts the encoded map keys with their values
+func (opts binaryOptions) sortEntries(keys, values [][]byte) {
+	if !opts.deterministic {
+		return
+	}
+	indexes := make([]int, len(keys))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return bytes.Compare(keys[indexes[i]], keys[indexes[j]]) < 0
+	})
+	sortedKeys := make([][]byte, len(keys))
+	sortedValues := make([][]byte, len(values))
+	for i, index := range indexes {
+		sortedKeys[i] = keys[index]
+		sortedValues[i] = values[index]
+	}
+	copy(keys, sortedKeys)
+	copy(values, sortedValues)
+}
+
+// normalizeValue converts values of other types (Mapper, structs, typed maps
+// and slices, ...) to the JSON data model: StringKeyMap, []any, string,
+// bool, numbers and nil
+func normalizeValue(value any) (any, error) {
+	if mapper, ok := value.(Mapper); ok {
+		return mapper.Map(), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var result any
+	if err = decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseNumber converts json.Number to int64, uint64 or float64
+func parseNumber(number json.Number) (any, error) {
+	str := string(number)
+	if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return i, nil
+	} else if u, err := strconv.ParseUint(str, 10, 64); err == nil {
+		return u, nil
+	}
+	return strconv.ParseFloat(str, 64)
+}
+
+// binaryReader reads the input of binary decoders
+type binaryReader struct {
+	data []byte
+	pos  int
+}
+
+func (reader *binaryReader) remaining() int {
+	return len(reader.data) - reader.pos
+}
+
+func (reader *binaryReader) readByte() (byte, error) {
+	if reader.pos >= len(reader.data) {
+		return 0, ErrBinaryFormat
+	}
+	ch := reader.data[reader.pos]
+	reader.pos++
+	return ch, nil
+}
+
+func (reader *binaryReader) read(size uint64) ([]byte, error) {
+	if size > uint64(reader.remaining()) {
+		return nil, ErrBinaryFormat
+	}
+	end := reader.pos + int(size)
+	data := reader.data[reader.pos:end]
+	reader.pos = end
+	return data, nil
+}
+
+// readUint reads a big-endian unsigned integer with the size (1, 2, 4 or 8)
+func (reader *binaryReader) readUint(size int) (uint64, error) {
+	data, err := reader.read(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	var value uint64
+	for _, ch := range data {
+		value = value<<8 | uint64(ch)
+	}
+	return value, nil
+}
+
+// putUint writes a big-endian unsigned integer with the size (1, 2, 4 or 8)
+func putUint(buf *bytes.Buffer, value uint64, size int) {
+	for shift := (size - 1) * 8; shift >= 0; shift -= 8 {
+		buf.WriteByte(byte(value >> uint(shift)))
+	}
+}
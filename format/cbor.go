@@ -0,0 +1,459 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"unicode/utf8"
+
+	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/mkm-go/types"
+)
+
+func NewCBORCoder() ObjectCoder {
+	return &CBORCoder{}
+}
+
+// NewCBORCoderWithOptions creates CBOR coder with options
+//
+// Parameters:
+//   - deterministic - sort map keys (RFC 8949 §4.2.1), for signing
+//   - binaryFields  - fields to send as byte strings when their values are Base64,
+//     e.g.: DefaultBinaryFields
+func NewCBORCoderWithOptions(deterministic bool, binaryFields []string) ObjectCoder {
+	return &CBORCoder{
+		binaryOptions: newBinaryOptions(deterministic, binaryFields),
+	}
+}
+
+// CBORCoder encodes/decodes objects with CBOR (RFC 8949)
+//
+// Integers and floats always use the shortest form that keeps the value,
+// indefinite lengths are never used by the encoder (but accepted by the decoder).
+// Byte strings are decoded as standard Base64 strings, and tags are skipped,
+// so the result is the same as decoding the JSON of the object
+type CBORCoder struct {
+	//ObjectCoder
+	binaryOptions
+}
+
+// Override
+func (coder CBORCoder) Encode(object any) string {
+	buf := &bytes.Buffer{}
+	if err := coder.encode(buf, object); err != nil {
+		//panic("failed to encode to CBOR")
+		return ""
+	}
+	return buf.String()
+}
+
+// Override
+func (coder CBORCoder) Decode(str string) any {
+	reader := &binaryReader{data: []byte(str)}
+	value, err := coder.decode(reader, 0)
+	if err != nil || reader.remaining() > 0 {
+		//panic("failed to decode CBOR")
+		return nil
+	}
+	switch value.(type) {
+	case StringKeyMap, []any:
+		return value
+	}
+	return nil
+}
+
+//
+//  Encoding
+//
+
+const (
+	cborUnsigned = 0
+	cborNegative = 1
+	cborBytes    = 2
+	cborText     = 3
+	cborArray    = 4
+	cborMap      = 5
+	cborTag      = 6
+	cborSimple   = 7
+)
+
+func cborHead(buf *bytes.Buffer, major byte, n uint64) {
+	major <<= 5
+	if n < 24 {
+		buf.WriteByte(major | byte(n))
+	} else if n <= math.MaxUint8 {
+		buf.WriteByte(major | 24)
+		putUint(buf, n, 1)
+	} else if n <= math.MaxUint16 {
+		buf.WriteByte(major | 25)
+		putUint(buf, n, 2)
+	} else if n <= math.MaxUint32 {
+		buf.WriteByte(major | 26)
+		putUint(buf, n, 4)
+	} else {
+		buf.WriteByte(major | 27)
+		putUint(buf, n, 8)
+	}
+}
+
+func cborInt(buf *bytes.Buffer, i int64) {
+	if i >= 0 {
+		cborHead(buf, cborUnsigned, uint64(i))
+	} else {
+		cborHead(buf, cborNegative, uint64(-1-i))
+	}
+}
+
+func cborFloat(buf *bytes.Buffer, f float64) {
+	if math.IsNaN(f) {
+		// canonical NaN
+		buf.Write([]byte{0xF9, 0x7E, 0x00})
+		return
+	}
+	f32 := float32(f)
+	if float64(f32) != f {
+		buf.WriteByte(0xFB)
+		putUint(buf, math.Float64bits(f), 8)
+	} else if half, ok := float16Bits(f32); ok {
+		buf.WriteByte(0xF9)
+		putUint(buf, uint64(half), 2)
+	} else {
+		buf.WriteByte(0xFA)
+		putUint(buf, uint64(math.Float32bits(f32)), 4)
+	}
+}
+
+func (coder CBORCoder) encode(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(0xF6)
+	case bool:
+		if v {
+			buf.WriteByte(0xF5)
+		} else {
+			buf.WriteByte(0xF4)
+		}
+	case string:
+		cborHead(buf, cborText, uint64(len(v)))
+		buf.WriteString(v)
+	case []byte:
+		cborHead(buf, cborBytes, uint64(len(v)))
+		buf.Write(v)
+	// integer
+	case int:
+		cborInt(buf, int64(v))
+	case int8:
+		cborInt(buf, int64(v))
+	case int16:
+		cborInt(buf, int64(v))
+	case int32:
+		cborInt(buf, int64(v))
+	case int64:
+		cborInt(buf, v)
+	case uint:
+		cborHead(buf, cborUnsigned, uint64(v))
+	case uint8:
+		cborHead(buf, cborUnsigned, uint64(v))
+	case uint16:
+		cborHead(buf, cborUnsigned, uint64(v))
+	case uint32:
+		cborHead(buf, cborUnsigned, uint64(v))
+	case uint64:
+		cborHead(buf, cborUnsigned, v)
+	// float number
+	case float32:
+		cborFloat(buf, float64(v))
+	case float64:
+		cborFloat(buf, v)
+	case json.Number:
+		number, err := parseNumber(v)
+		if err != nil {
+			return err
+		}
+		return coder.encode(buf, number)
+	// containers
+	case []any:
+		cborHead(buf, cborArray, uint64(len(v)))
+		for _, item := range v {
+			if err := coder.encode(buf, item); err != nil {
+				return err
+			}
+		}
+	case StringKeyMap:
+		keys := make([][]byte, 0, len(v))
+		values := make([][]byte, 0, len(v))
+		for key, item := range v {
+			kb := &bytes.Buffer{}
+			cborHead(kb, cborText, uint64(len(key)))
+			kb.WriteString(key)
+			vb := &bytes.Buffer{}
+			if data, ok := coder.binaryField(key, item); ok {
+				cborHead(vb, cborBytes, uint64(len(data)))
+				vb.Write(data)
+			} else if err := coder.encode(vb, item); err != nil {
+				return err
+			}
+			keys = append(keys, kb.Bytes())
+			values = append(values, vb.Bytes())
+		}
+		coder.sortEntries(keys, values)
+		cborHead(buf, cborMap, uint64(len(keys)))
+		for i := range keys {
+			buf.Write(keys[i])
+			buf.Write(values[i])
+		}
+	default:
+		normalized, err := normalizeValue(value)
+		if err != nil {
+			return err
+		}
+		return coder.encode(buf, normalized)
+	}
+	return nil
+}
+
+//
+//  Decoding
+//
+
+// cborArgument reads the argument of the head,
+// returns indefinite = true for additional information 31
+func cborArgument(reader *binaryReader, info byte) (n uint64, indefinite bool, err error) {
+	switch {
+	case info < 24:
+		return uint64(info), false, nil
+	case info <= 27:
+		n, err = reader.readUint(1 << (info - 24))
+		return n, false, err
+	case info == 31:
+		return 0, true, nil
+	}
+	return 0, false, ErrBinaryFormat
+}
+
+// cborBreak checks and skips the "break" stop code of indefinite length items
+func cborBreak(reader *binaryReader) bool {
+	if reader.remaining() > 0 && reader.data[reader.pos] == 0xFF {
+		reader.pos++
+		return true
+	}
+	return false
+}
+
+func (coder CBORCoder) decode(reader *binaryReader, depth int) (any, error) {
+	if depth > maxBinaryDepth {
+		return nil, ErrBinaryFormat
+	}
+	head, err := reader.readByte()
+	if err != nil {
+		return nil, err
+	}
+	major := head >> 5
+	info := head & 0x1F
+	if major == cborSimple {
+		return cborSimpleValue(reader, info)
+	}
+	n, indefinite, err := cborArgument(reader, info)
+	if err != nil {
+		return nil, err
+	} else if indefinite && (major < cborBytes || major > cborMap) {
+		return nil, ErrBinaryFormat
+	}
+	switch major {
+	case cborUnsigned:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case cborNegative:
+		if n > math.MaxInt64 {
+			return -1 - float64(n), nil
+		}
+		return -1 - int64(n), nil
+	case cborBytes, cborText:
+		data, err := cborString(reader, major, n, indefinite)
+		if err != nil {
+			return nil, err
+		} else if major == cborBytes {
+			return base64.StdEncoding.EncodeToString(data), nil
+		} else if !utf8.Valid(data) {
+			return nil, ErrBinaryFormat
+		}
+		return string(data), nil
+	case cborArray:
+		if !indefinite && n > uint64(reader.remaining()) {
+			return nil, ErrBinaryFormat
+		}
+		array := make([]any, 0, n)
+		for i := uint64(0); indefinite || i < n; i++ {
+			if indefinite && cborBreak(reader) {
+				break
+			}
+			item, err := coder.decode(reader, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, item)
+		}
+		return array, nil
+	case cborMap:
+		if !indefinite && n > uint64(reader.remaining()) {
+			return nil, ErrBinaryFormat
+		}
+		dict := make(StringKeyMap, n)
+		for i := uint64(0); indefinite || i < n; i++ {
+			if indefinite && cborBreak(reader) {
+				break
+			}
+			key, err := coder.decode(reader, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			name, ok := key.(string)
+			if !ok {
+				return nil, ErrBinaryType
+			}
+			item, err := coder.decode(reader, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			dict[name] = item
+		}
+		return dict, nil
+	default:
+		// tag: ignore it, decode the tagged item
+		return coder.decode(reader, depth+1)
+	}
+}
+
+func cborString(reader *binaryReader, major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		return reader.read(n)
+	}
+	// concatenate definite length chunks
+	var data []byte
+	for !cborBreak(reader) {
+		head, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		} else if head>>5 != major {
+			return nil, ErrBinaryFormat
+		}
+		size, indefinite, err := cborArgument(reader, head&0x1F)
+		if err != nil || indefinite {
+			return nil, ErrBinaryFormat
+		}
+		chunk, err := reader.read(size)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk...)
+	}
+	return data, nil
+}
+
+func cborSimpleValue(reader *binaryReader, info byte) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		// null, undefined
+		return nil, nil
+	case 25:
+		bits, err := reader.readUint(2)
+		return float16Value(uint16(bits)), err
+	case 26:
+		bits, err := reader.readUint(4)
+		return float64(math.Float32frombits(uint32(bits))), err
+	case 27:
+		bits, err := reader.readUint(8)
+		return math.Float64frombits(bits), err
+	}
+	return nil, ErrBinaryType
+}
+
+//
+//  Half precision float
+//
+
+// float16Bits converts float32 to IEEE 754 half precision,
+// returns false when the value cannot be kept exactly
+func float16Bits(f float32) (uint16, bool) {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23&0xFF) - 127
+	mant := bits & 0x7FFFFF
+	switch {
+	case exp == 128:
+		// Infinity (NaN was handled by the caller)
+		return sign | 0x7C00, mant == 0
+	case exp == -127 && mant == 0:
+		// zero
+		return sign, true
+	case -14 <= exp && exp <= 15:
+		// normal
+		if mant&0x1FFF != 0 {
+			return 0, false
+		}
+		return sign | uint16(exp+15)<<10 | uint16(mant>>13), true
+	case -24 <= exp && exp < -14:
+		// subnormal
+		mant |= 0x800000
+		shift := uint(-exp - 1)
+		if mant&(1<<shift-1) != 0 {
+			return 0, false
+		}
+		return sign | uint16(mant>>shift), true
+	}
+	return 0, false
+}
+
+func float16Value(bits uint16) float64 {
+	exp := int(bits >> 10 & 0x1F)
+	mant := float64(bits & 0x3FF)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if bits&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
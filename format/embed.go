@@ -0,0 +1,55 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	. "github.com/dimchat/core-go/format"
+	. "github.com/dimchat/core-go/rfc"
+	. "github.com/dimchat/mkm-go/format"
+)
+
+// URL_ESCAPED is the default encoding of data URI (RFC 2397),
+// which has no encoding name in the header
+//
+//goland:noinspection GoSnakeCaseUsage
+const URL_ESCAPED = ""
+
+// NewEmbedDataWithEncoding creates data URI with the encoding (BASE_64, BASE_58,
+// HEX or URL_ESCAPED), the data coder must be registered by 'SetDataCoder()'
+//
+//	"data:image/png;base64,{BASE64_ENCODE}"
+//	"data:application/octet-stream;hex,{HEX_ENCODE}"
+//	"data:text/plain;charset=utf-8,{URL_ESCAPED}"
+//
+// Returns: nil on encoding not supported
+func NewEmbedDataWithEncoding(mimeType, encoding string, body []byte) TransportableData {
+	if GetDataCoder(encoding) == nil {
+		//panic("data encoding not supported: " + encoding)
+		return nil
+	}
+	head := NewDataHeader(mimeType, encoding, nil)
+	return NewEmbedData("", body, nil, head)
+}
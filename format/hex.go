@@ -46,9 +46,14 @@ func (HexCoder) Encode(data []byte) string {
 
 // Override
 func (HexCoder) Decode(string string) []byte {
+	// "0x" prefix is allowed
+	if len(string) > 1 && string[0] == '0' && (string[1] == 'x' || string[1] == 'X') {
+		string = string[2:]
+	}
 	bytes, err := hex.DecodeString(string)
 	if err != nil {
-		panic(err)
+		//panic(err)
+		return nil
 	}
 	return bytes
 }
@@ -0,0 +1,54 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHexCoder(t *testing.T) {
+	coder := NewHexCoder()
+	data := []byte{0x00, 0x01, 0xAB, 0xCD, 0xEF, 0xFF}
+	if text := coder.Encode(data); text != "0001abcdefff" {
+		t.Errorf("Encode() = %s", text)
+	}
+	// lower/upper case, with or without "0x" prefix
+	for _, text := range []string{"0001abcdefff", "0001ABCDEFFF", "0x0001abcdefff", "0X0001ABCDEFFF"} {
+		if out := coder.Decode(text); !bytes.Equal(out, data) {
+			t.Errorf("Decode(%s) = %x", text, out)
+		}
+	}
+	if out := coder.Decode("0x"); out == nil || len(out) != 0 {
+		t.Errorf("Decode(0x) = %v", out)
+	}
+	// invalid input returns nil instead of panicking
+	for _, text := range []string{"0", "abc", "0xabc", "zz", "0x0x00", "00 11"} {
+		if out := coder.Decode(text); out != nil {
+			t.Errorf("Decode(%q) = %x", text, out)
+		}
+	}
+}
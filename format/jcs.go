@@ -0,0 +1,270 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	. "github.com/dimchat/mkm-go/format"
+)
+
+func NewCanonicalJSONCoder() ObjectCoder {
+	return &CanonicalJSONCoder{}
+}
+
+// CanonicalJSONCoder encodes objects with the JSON Canonicalization Scheme
+// (RFC 8785), so the same object always gets the same string, no matter
+// which language or map ordering produced it.
+//
+// Signed structures (document "data", ...) are signed over JSON strings,
+// to get reproducible signatures across DIM clients, set it as JSON coder:
+//
+//	SetJSONCoder(NewCanonicalJSONCoder())
+//
+// or sign the documents with mkm.SignDocumentCanonical() only.
+//
+// Integers that a double cannot hold exactly (beyond 2^53) are not rounded,
+// encoding fails instead; decoding is the same as JSONCoder
+type CanonicalJSONCoder struct {
+	//ObjectCoder
+}
+
+// Override
+func (CanonicalJSONCoder) Encode(object any) string {
+	str, err := CanonicalJSONEncode(object)
+	if err == nil {
+		return str
+	}
+	//panic("failed to encode to canonical JSON string")
+	return ""
+}
+
+// Override
+func (CanonicalJSONCoder) Decode(str string) any {
+	return JSONCoder{}.Decode(str)
+}
+
+var ErrJSONNumber = errors.New("jcs: NaN and Infinity are not allowed")
+
+var ErrJSONInteger = errors.New("jcs: integer cannot be represented exactly as a double")
+
+// CanonicalJSONEncode serializes the object as RFC 8785 canonical JSON:
+// no whitespaces, object members sorted by UTF-16 code units of the keys,
+// numbers formatted as ECMAScript doubles, and minimal string escaping
+func CanonicalJSONEncode(object any) (string, error) {
+	// normalize to the JSON data model first (maps, arrays, numbers, ...)
+	data, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err = jcsWrite(&sb, value); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func jcsWrite(sb *strings.Builder, value any) error {
+	switch v := value.(type) {
+	case nil:
+		sb.WriteString("null")
+	case bool:
+		if v {
+			sb.WriteString("true")
+		} else {
+			sb.WriteString("false")
+		}
+	case json.Number:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return err
+		} else if !jcsExactInteger(string(v), f) {
+			return ErrJSONInteger
+		}
+		str, err := jcsNumber(f)
+		if err != nil {
+			return err
+		}
+		sb.WriteString(str)
+	case string:
+		jcsString(sb, v)
+	case []any:
+		sb.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			if err := jcsWrite(sb, item); err != nil {
+				return err
+			}
+		}
+		sb.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return jcsKeyLess(keys[i], keys[j])
+		})
+		sb.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			jcsString(sb, key)
+			sb.WriteByte(':')
+			if err := jcsWrite(sb, v[key]); err != nil {
+				return err
+			}
+		}
+		sb.WriteByte('}')
+	default:
+		return errors.New("jcs: unexpected value type")
+	}
+	return nil
+}
+
+// jcsExactInteger checks whether the integer literal survives the conversion
+// to double, big integers (beyond 2^53) must not be rounded silently.
+// Non-integer literals (with '.', 'e' or 'E') are always accepted
+func jcsExactInteger(text string, f float64) bool {
+	if strings.ContainsAny(text, ".eE") {
+		return true
+	}
+	n, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		return false
+	}
+	i, _ := big.NewFloat(f).Int(nil)
+	return n.Cmp(i) == 0
+}
+
+// jcsKeyLess compares two keys by their UTF-16 code units
+func jcsKeyLess(a, b string) bool {
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// jcsString writes the string with only the mandatory escapes:
+// '"', '\\' and the control characters (U+0000 ~ U+001F)
+func jcsString(sb *strings.Builder, str string) {
+	const hex = "0123456789abcdef"
+	sb.WriteByte('"')
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch ch {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if ch < 0x20 {
+				sb.WriteString(`\u00`)
+				sb.WriteByte(hex[ch>>4])
+				sb.WriteByte(hex[ch&0x0F])
+			} else {
+				sb.WriteByte(ch)
+			}
+		}
+	}
+	sb.WriteByte('"')
+}
+
+// jcsNumber formats the double as ECMAScript Number.prototype.toString()
+func jcsNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", ErrJSONNumber
+	} else if f == 0 {
+		// including -0
+		return "0", nil
+	}
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+	// shortest round-trip digits: "d.ddde±xx"
+	str := strconv.FormatFloat(f, 'e', -1, 64)
+	pos := strings.IndexByte(str, 'e')
+	exp, err := strconv.Atoi(str[pos+1:])
+	if err != nil {
+		return "", err
+	}
+	digits := strings.Replace(str[:pos], ".", "", 1)
+	k := len(digits)
+	n := exp + 1 // value = 0.digits * 10^n
+	switch {
+	case k <= n && n <= 21:
+		return sign + digits + strings.Repeat("0", n-k), nil
+	case 0 < n && n <= 21:
+		return sign + digits[:n] + "." + digits[n:], nil
+	case -6 < n && n <= 0:
+		return sign + "0." + strings.Repeat("0", -n) + digits, nil
+	}
+	// exponential notation
+	e := n - 1
+	expSign := "+"
+	if e < 0 {
+		expSign = "-"
+		e = -e
+	}
+	mantissa := digits[:1]
+	if k > 1 {
+		mantissa += "." + digits[1:]
+	}
+	return sign + mantissa + "e" + expSign + strconv.Itoa(e), nil
+}
@@ -0,0 +1,146 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestCanonicalJSONExample(t *testing.T) {
+	// RFC 8785, 3.2.2
+	text := `{"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],` +
+		` "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/", "literals": [null, true, false]}`
+	expected := `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],` +
+		`"string":"€$\u000f\nA'B\"\\\\\"/"}`
+	var value any
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := CanonicalJSONEncode(value); err != nil || out != expected {
+		t.Errorf("CanonicalJSONEncode() = %s, %v", out, err)
+	}
+	if out := NewCanonicalJSONCoder().Encode(value); out != expected {
+		t.Errorf("Encode() = %s", out)
+	}
+}
+
+func TestCanonicalJSONSorting(t *testing.T) {
+	// RFC 8785, 3.2.3: sorted by UTF-16 code units, not by UTF-8 bytes
+	value := map[string]any{
+		"\u20ac":     "Euro Sign",
+		"\r":         "Carriage Return",
+		"\ufb33":     "Hebrew Letter Dalet With Dagesh",
+		"1":          "One",
+		"\U0001f600": "Emoji: Grinning Face",
+		"\u0080":     "Control",
+		"\u00f6":     "Latin Small Letter O With Diaeresis",
+	}
+	expected := "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\"," +
+		"\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\"," +
+		"\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}"
+	if out, err := CanonicalJSONEncode(value); err != nil || out != expected {
+		t.Errorf("CanonicalJSONEncode() = %s, %v", out, err)
+	}
+}
+
+func TestCanonicalJSONNumbers(t *testing.T) {
+	// RFC 8785, Appendix B
+	vectors := []struct {
+		bits     uint64
+		expected string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	}
+	for _, v := range vectors {
+		out, err := jcsNumber(math.Float64frombits(v.bits))
+		if err != nil || out != v.expected {
+			t.Errorf("%016x: %s, %v, expected %s", v.bits, out, err, v.expected)
+		}
+	}
+	// NaN & Infinity
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := jcsNumber(f); err != ErrJSONNumber {
+			t.Errorf("%v: %v", f, err)
+		}
+	}
+}
+
+func TestCanonicalJSONIntegers(t *testing.T) {
+	// exact doubles are fine
+	for _, value := range []any{
+		int64(9007199254740992),
+		int64(-9007199254740992),
+		uint64(1) << 60,
+		json.Number("9007199254740992"),
+		json.Number("9007199254740993.0"), // not an integer literal
+	} {
+		if _, err := CanonicalJSONEncode(map[string]any{"sn": value}); err != nil {
+			t.Errorf("%v: %v", value, err)
+		}
+	}
+	// integers beyond 2^53 must not be rounded silently
+	for _, value := range []any{
+		int64(9007199254740993),
+		int64(-9007199254740993),
+		uint64(18446744073709551615),
+		json.Number("9007199254740993"),
+		json.Number("123456789012345678901234567890"),
+	} {
+		if out, err := CanonicalJSONEncode(map[string]any{"sn": value}); err != ErrJSONInteger {
+			t.Errorf("%v: %s, %v", value, out, err)
+		}
+	}
+	// from the lossless coder
+	dict := NewLosslessJSONCoder().Decode(`{"sn":9007199254740993}`)
+	if out := NewCanonicalJSONCoder().Encode(dict); out != "" {
+		t.Errorf("Encode() = %s", out)
+	}
+}
@@ -26,7 +26,11 @@
 package format
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
 
 	. "github.com/dimchat/mkm-go/format"
 	. "github.com/dimchat/mkm-go/types"
@@ -36,8 +40,25 @@ func NewJSONCoder() ObjectCoder {
 	return &JSONCoder{}
 }
 
+// NewLosslessJSONCoder creates JSON coder which keeps all numbers exactly
+func NewLosslessJSONCoder() ObjectCoder {
+	return &JSONCoder{lossless: true}
+}
+
+// JSONCoder encodes/decodes JSON objects
+//
+// By default, all numbers are decoded as float64, which rounds integers
+// above 2^53 (serial numbers, timestamps in milliseconds, amounts, ...);
+// in lossless mode, integers are decoded as int64 (or uint64 when too big),
+// other numbers are kept as json.Number, so they can be encoded back
+// without any change.
+//
+// The number values can still be read by the Convert* functions after
+// registering the number converter (see types.NewNumberConverter)
 type JSONCoder struct {
 	//ObjectCoder
+
+	lossless bool
 }
 
 // Override
@@ -51,13 +72,13 @@ func (JSONCoder) Encode(object any) string {
 }
 
 // Override
-func (JSONCoder) Decode(str string) any {
+func (coder JSONCoder) Decode(str string) any {
 	bytes := []byte(str)
 	for _, ch := range bytes {
 		if ch == '{' {
 			// decode to map
 			var dict StringKeyMap
-			err := json.Unmarshal(bytes, &dict)
+			err := coder.unmarshal(bytes, &dict)
 			if err == nil {
 				return dict
 			}
@@ -65,7 +86,7 @@ func (JSONCoder) Decode(str string) any {
 		} else if ch == '[' {
 			// decode to array
 			var array []any
-			err := json.Unmarshal(bytes, &array)
+			err := coder.unmarshal(bytes, &array)
 			if err == nil {
 				return array
 			}
@@ -78,3 +99,45 @@ func (JSONCoder) Decode(str string) any {
 	//panic(bytes)
 	return nil
 }
+
+func (coder JSONCoder) unmarshal(data []byte, v any) error {
+	if !coder.lossless {
+		return json.Unmarshal(data, v)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	} else if _, err = decoder.Token(); err != io.EOF {
+		// extra data after the value
+		return errors.New("json: invalid character after top-level value")
+	}
+	switch container := v.(type) {
+	case *StringKeyMap:
+		exactNumbers(*container)
+	case *[]any:
+		exactNumbers(*container)
+	}
+	return nil
+}
+
+// exactNumbers replaces integer json.Number in the container with int64/uint64
+func exactNumbers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = exactNumbers(item)
+		}
+	case []any:
+		for index, item := range v {
+			v[index] = exactNumbers(item)
+		}
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return i
+		} else if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u
+		}
+	}
+	return value
+}
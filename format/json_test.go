@@ -0,0 +1,88 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/dimchat/mkm-go/types"
+)
+
+func TestLosslessJSON(t *testing.T) {
+	text := `{"sn":9007199254740993,"time":1700000000123,"neg":-9223372036854775808,` +
+		`"max":18446744073709551615,"big":123456789012345678901234567890,` +
+		`"amount":0.1000000000000000055511151231257827,"list":[9007199254740995,1.5e300]}`
+	coder := NewLosslessJSONCoder()
+	dict, ok := coder.Decode(text).(StringKeyMap)
+	if !ok {
+		t.Fatalf("failed to decode: %s", text)
+	}
+	// integers are exact
+	if v, ok := dict["sn"].(int64); !ok || v != 9007199254740993 {
+		t.Errorf("sn = %v (%T)", dict["sn"], dict["sn"])
+	}
+	if v, ok := dict["neg"].(int64); !ok || v != -9223372036854775808 {
+		t.Errorf("neg = %v (%T)", dict["neg"], dict["neg"])
+	}
+	if v, ok := dict["max"].(uint64); !ok || v != 18446744073709551615 {
+		t.Errorf("max = %v (%T)", dict["max"], dict["max"])
+	}
+	list := dict["list"].([]any)
+	if v, ok := list[0].(int64); !ok || v != 9007199254740995 {
+		t.Errorf("list[0] = %v (%T)", list[0], list[0])
+	}
+	// others are kept as json.Number
+	if v, ok := dict["big"].(json.Number); !ok || v != "123456789012345678901234567890" {
+		t.Errorf("big = %v (%T)", dict["big"], dict["big"])
+	}
+	if v, ok := dict["amount"].(json.Number); !ok || v != "0.1000000000000000055511151231257827" {
+		t.Errorf("amount = %v (%T)", dict["amount"], dict["amount"])
+	}
+	// encode back without any change (encoding/json sorts the keys)
+	expected := `{"amount":0.1000000000000000055511151231257827,"big":123456789012345678901234567890,` +
+		`"list":[9007199254740995,1.5e300],"max":18446744073709551615,"neg":-9223372036854775808,` +
+		`"sn":9007199254740993,"time":1700000000123}`
+	if out := coder.Encode(dict); out != expected {
+		t.Errorf("Encode() = %s", out)
+	}
+	// top-level array
+	if array, ok := coder.Decode(`[18446744073709551615, 2]`).([]any); !ok || array[0] != uint64(18446744073709551615) {
+		t.Errorf("array = %v", array)
+	}
+	// trailing data
+	if coder.Decode(`{"a":1} {"b":2}`) != nil {
+		t.Errorf("trailing data accepted")
+	}
+}
+
+func TestDefaultJSONNumbers(t *testing.T) {
+	// the default coder is not changed: float64, rounded above 2^53
+	dict := NewJSONCoder().Decode(`{"sn":9007199254740993}`).(StringKeyMap)
+	if v, ok := dict["sn"].(float64); !ok || v != 9007199254740992 {
+		t.Errorf("sn = %v (%T)", dict["sn"], dict["sn"])
+	}
+}
@@ -0,0 +1,416 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"unicode/utf8"
+
+	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/mkm-go/types"
+)
+
+func NewMessagePackCoder() ObjectCoder {
+	return &MessagePackCoder{}
+}
+
+// NewMessagePackCoderWithOptions creates MessagePack coder with options
+//
+// Parameters:
+//   - deterministic - sort map keys by their encoded bytes, for signing
+//   - binaryFields  - fields to send as 'bin' when their values are Base64,
+//     e.g.: DefaultBinaryFields
+func NewMessagePackCoderWithOptions(deterministic bool, binaryFields []string) ObjectCoder {
+	return &MessagePackCoder{
+		binaryOptions: newBinaryOptions(deterministic, binaryFields),
+	}
+}
+
+// MessagePackCoder encodes/decodes objects with MessagePack
+//
+// Integers always use the smallest format, floats use float32 when exact.
+// 'bin' is decoded as standard Base64 string, 'ext' is not supported
+type MessagePackCoder struct {
+	//ObjectCoder
+	binaryOptions
+}
+
+// Override
+func (coder MessagePackCoder) Encode(object any) string {
+	buf := &bytes.Buffer{}
+	if err := coder.encode(buf, object); err != nil {
+		//panic("failed to encode to MessagePack")
+		return ""
+	}
+	return buf.String()
+}
+
+// Override
+func (coder MessagePackCoder) Decode(str string) any {
+	reader := &binaryReader{data: []byte(str)}
+	value, err := coder.decode(reader, 0)
+	if err != nil || reader.remaining() > 0 {
+		//panic("failed to decode MessagePack")
+		return nil
+	}
+	switch value.(type) {
+	case StringKeyMap, []any:
+		return value
+	}
+	return nil
+}
+
+//
+//  Encoding
+//
+
+func msgpackUint(buf *bytes.Buffer, u uint64) {
+	if u <= 0x7F {
+		buf.WriteByte(byte(u))
+	} else if u <= math.MaxUint8 {
+		buf.WriteByte(0xCC)
+		putUint(buf, u, 1)
+	} else if u <= math.MaxUint16 {
+		buf.WriteByte(0xCD)
+		putUint(buf, u, 2)
+	} else if u <= math.MaxUint32 {
+		buf.WriteByte(0xCE)
+		putUint(buf, u, 4)
+	} else {
+		buf.WriteByte(0xCF)
+		putUint(buf, u, 8)
+	}
+}
+
+func msgpackInt(buf *bytes.Buffer, i int64) {
+	if i >= 0 {
+		msgpackUint(buf, uint64(i))
+	} else if i >= -32 {
+		buf.WriteByte(byte(i))
+	} else if i >= math.MinInt8 {
+		buf.WriteByte(0xD0)
+		putUint(buf, uint64(i), 1)
+	} else if i >= math.MinInt16 {
+		buf.WriteByte(0xD1)
+		putUint(buf, uint64(i), 2)
+	} else if i >= math.MinInt32 {
+		buf.WriteByte(0xD2)
+		putUint(buf, uint64(i), 4)
+	} else {
+		buf.WriteByte(0xD3)
+		putUint(buf, uint64(i), 8)
+	}
+}
+
+func msgpackFloat(buf *bytes.Buffer, f float64) {
+	if f32 := float32(f); float64(f32) == f {
+		buf.WriteByte(0xCA)
+		putUint(buf, uint64(math.Float32bits(f32)), 4)
+	} else {
+		buf.WriteByte(0xCB)
+		putUint(buf, math.Float64bits(f), 8)
+	}
+}
+
+func msgpackString(buf *bytes.Buffer, str string) {
+	size := uint64(len(str))
+	if size < 32 {
+		buf.WriteByte(0xA0 | byte(size))
+	} else if size <= math.MaxUint8 {
+		buf.WriteByte(0xD9)
+		putUint(buf, size, 1)
+	} else if size <= math.MaxUint16 {
+		buf.WriteByte(0xDA)
+		putUint(buf, size, 2)
+	} else {
+		buf.WriteByte(0xDB)
+		putUint(buf, size, 4)
+	}
+	buf.WriteString(str)
+}
+
+func msgpackBinary(buf *bytes.Buffer, data []byte) {
+	size := uint64(len(data))
+	if size <= math.MaxUint8 {
+		buf.WriteByte(0xC4)
+		putUint(buf, size, 1)
+	} else if size <= math.MaxUint16 {
+		buf.WriteByte(0xC5)
+		putUint(buf, size, 2)
+	} else {
+		buf.WriteByte(0xC6)
+		putUint(buf, size, 4)
+	}
+	buf.Write(data)
+}
+
+// msgpackContainer writes the head of array (fix = 0x90) or map (fix = 0x80)
+func msgpackContainer(buf *bytes.Buffer, fix byte, count int) {
+	size := uint64(count)
+	if size < 16 {
+		buf.WriteByte(fix | byte(size))
+	} else if size <= math.MaxUint16 {
+		// 0xDC: array 16, 0xDE: map 16
+		buf.WriteByte(0xDC + (0x90-fix)/8)
+		putUint(buf, size, 2)
+	} else {
+		// 0xDD: array 32, 0xDF: map 32
+		buf.WriteByte(0xDD + (0x90-fix)/8)
+		putUint(buf, size, 4)
+	}
+}
+
+func (coder MessagePackCoder) encode(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(0xC0)
+	case bool:
+		if v {
+			buf.WriteByte(0xC3)
+		} else {
+			buf.WriteByte(0xC2)
+		}
+	case string:
+		msgpackString(buf, v)
+	case []byte:
+		msgpackBinary(buf, v)
+	// integer
+	case int:
+		msgpackInt(buf, int64(v))
+	case int8:
+		msgpackInt(buf, int64(v))
+	case int16:
+		msgpackInt(buf, int64(v))
+	case int32:
+		msgpackInt(buf, int64(v))
+	case int64:
+		msgpackInt(buf, v)
+	case uint:
+		msgpackUint(buf, uint64(v))
+	case uint8:
+		msgpackUint(buf, uint64(v))
+	case uint16:
+		msgpackUint(buf, uint64(v))
+	case uint32:
+		msgpackUint(buf, uint64(v))
+	case uint64:
+		msgpackUint(buf, v)
+	// float number
+	case float32:
+		msgpackFloat(buf, float64(v))
+	case float64:
+		msgpackFloat(buf, v)
+	case json.Number:
+		number, err := parseNumber(v)
+		if err != nil {
+			return err
+		}
+		return coder.encode(buf, number)
+	// containers
+	case []any:
+		msgpackContainer(buf, 0x90, len(v))
+		for _, item := range v {
+			if err := coder.encode(buf, item); err != nil {
+				return err
+			}
+		}
+	case StringKeyMap:
+		keys := make([][]byte, 0, len(v))
+		values := make([][]byte, 0, len(v))
+		for key, item := range v {
+			kb := &bytes.Buffer{}
+			msgpackString(kb, key)
+			vb := &bytes.Buffer{}
+			if data, ok := coder.binaryField(key, item); ok {
+				msgpackBinary(vb, data)
+			} else if err := coder.encode(vb, item); err != nil {
+				return err
+			}
+			keys = append(keys, kb.Bytes())
+			values = append(values, vb.Bytes())
+		}
+		coder.sortEntries(keys, values)
+		msgpackContainer(buf, 0x80, len(keys))
+		for i := range keys {
+			buf.Write(keys[i])
+			buf.Write(values[i])
+		}
+	default:
+		normalized, err := normalizeValue(value)
+		if err != nil {
+			return err
+		}
+		return coder.encode(buf, normalized)
+	}
+	return nil
+}
+
+//
+//  Decoding
+//
+
+func (coder MessagePackCoder) decode(reader *binaryReader, depth int) (any, error) {
+	if depth > maxBinaryDepth {
+		return nil, ErrBinaryFormat
+	}
+	head, err := reader.readByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case head <= 0x7F:
+		// positive fixint
+		return int64(head), nil
+	case head >= 0xE0:
+		// negative fixint
+		return int64(int8(head)), nil
+	case head <= 0x8F:
+		return coder.decodeMap(reader, uint64(head&0x0F), depth)
+	case head <= 0x9F:
+		return coder.decodeArray(reader, uint64(head&0x0F), depth)
+	case head <= 0xBF:
+		return msgpackText(reader, uint64(head&0x1F))
+	}
+	switch head {
+	case 0xC0:
+		return nil, nil
+	case 0xC2:
+		return false, nil
+	case 0xC3:
+		return true, nil
+	// bin 8/16/32
+	case 0xC4, 0xC5, 0xC6:
+		size, err := reader.readUint(1 << (head - 0xC4))
+		if err != nil {
+			return nil, err
+		}
+		data, err := reader.read(size)
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(data), nil
+	// float 32/64
+	case 0xCA:
+		bits, err := reader.readUint(4)
+		return float64(math.Float32frombits(uint32(bits))), err
+	case 0xCB:
+		bits, err := reader.readUint(8)
+		return math.Float64frombits(bits), err
+	// uint 8/16/32/64
+	case 0xCC, 0xCD, 0xCE, 0xCF:
+		u, err := reader.readUint(1 << (head - 0xCC))
+		if err != nil {
+			return nil, err
+		} else if u > math.MaxInt64 {
+			return u, nil
+		}
+		return int64(u), nil
+	// int 8/16/32/64
+	case 0xD0, 0xD1, 0xD2, 0xD3:
+		size := 1 << (head - 0xD0)
+		u, err := reader.readUint(size)
+		if err != nil {
+			return nil, err
+		}
+		// sign extension
+		shift := uint(64 - size*8)
+		return int64(u<<shift) >> shift, nil
+	// str 8/16/32
+	case 0xD9, 0xDA, 0xDB:
+		size, err := reader.readUint(1 << (head - 0xD9))
+		if err != nil {
+			return nil, err
+		}
+		return msgpackText(reader, size)
+	// array 16/32
+	case 0xDC, 0xDD:
+		count, err := reader.readUint(2 << (head - 0xDC))
+		if err != nil {
+			return nil, err
+		}
+		return coder.decodeArray(reader, count, depth)
+	// map 16/32
+	case 0xDE, 0xDF:
+		count, err := reader.readUint(2 << (head - 0xDE))
+		if err != nil {
+			return nil, err
+		}
+		return coder.decodeMap(reader, count, depth)
+	}
+	// 0xC1 (never used), ext & fixext
+	return nil, ErrBinaryType
+}
+
+func msgpackText(reader *binaryReader, size uint64) (any, error) {
+	data, err := reader.read(size)
+	if err != nil {
+		return nil, err
+	} else if !utf8.Valid(data) {
+		return nil, ErrBinaryFormat
+	}
+	return string(data), nil
+}
+
+func (coder MessagePackCoder) decodeArray(reader *binaryReader, count uint64, depth int) (any, error) {
+	if count > uint64(reader.remaining()) {
+		return nil, ErrBinaryFormat
+	}
+	array := make([]any, 0, count)
+	for i := uint64(0); i < count; i++ {
+		item, err := coder.decode(reader, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, item)
+	}
+	return array, nil
+}
+
+func (coder MessagePackCoder) decodeMap(reader *binaryReader, count uint64, depth int) (any, error) {
+	if count > uint64(reader.remaining()) {
+		return nil, ErrBinaryFormat
+	}
+	dict := make(StringKeyMap, count)
+	for i := uint64(0); i < count; i++ {
+		key, err := coder.decode(reader, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, ErrBinaryType
+		}
+		item, err := coder.decode(reader, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		dict[name] = item
+	}
+	return dict, nil
+}
@@ -0,0 +1,104 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	. "github.com/dimchat/mkm-go/format"
+)
+
+func NewPercentCoder() DataCoder {
+	return &PercentCoder{}
+}
+
+// PercentCoder implements URL escaped encoding (RFC 3986 percent-encoding),
+// which is the default encoding of data URI (RFC 2397):
+//
+//	"data:text/plain;charset=utf-8,Hello%2C%20World!"
+//
+// All bytes except the unreserved characters are escaped as "%XX"
+type PercentCoder struct {
+	//DataCoder
+}
+
+// Override
+func (PercentCoder) Encode(data []byte) string {
+	const hex = "0123456789ABCDEF"
+	buf := make([]byte, 0, len(data))
+	for _, ch := range data {
+		if percentUnreserved(ch) {
+			buf = append(buf, ch)
+		} else {
+			buf = append(buf, '%', hex[ch>>4], hex[ch&0x0F])
+		}
+	}
+	return string(buf)
+}
+
+// Override
+func (PercentCoder) Decode(string string) []byte {
+	buf := make([]byte, 0, len(string))
+	for i := 0; i < len(string); i++ {
+		ch := string[i]
+		if ch != '%' {
+			buf = append(buf, ch)
+			continue
+		} else if i+2 >= len(string) {
+			//panic("percent encoding error")
+			return nil
+		}
+		hi, ok1 := unhex(string[i+1])
+		lo, ok2 := unhex(string[i+2])
+		if !ok1 || !ok2 {
+			//panic("percent encoding error")
+			return nil
+		}
+		buf = append(buf, hi<<4|lo)
+		i += 2
+	}
+	return buf
+}
+
+func percentUnreserved(ch byte) bool {
+	switch {
+	case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9':
+		return true
+	case ch == '-' || ch == '_' || ch == '.' || ch == '~':
+		return true
+	}
+	return false
+}
+
+func unhex(ch byte) (byte, bool) {
+	switch {
+	case '0' <= ch && ch <= '9':
+		return ch - '0', true
+	case 'a' <= ch && ch <= 'f':
+		return ch - 'a' + 10, true
+	case 'A' <= ch && ch <= 'F':
+		return ch - 'A' + 10, true
+	}
+	return 0, false
+}
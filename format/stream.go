@@ -0,0 +1,200 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package format
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+
+	. "github.com/dimchat/mkm-go/types"
+)
+
+var ErrJSONStream = errors.New("json stream: object expected")
+
+// NewJSONStreamDecoder creates decoder for a JSON array of objects,
+// or JSON Lines (one object per line), detected by the first character
+func NewJSONStreamDecoder(reader io.Reader) *JSONStreamDecoder {
+	return &JSONStreamDecoder{
+		reader: bufio.NewReader(reader),
+	}
+}
+
+// NewLosslessJSONStreamDecoder creates stream decoder which keeps numbers exactly,
+// same as NewLosslessJSONCoder()
+func NewLosslessJSONStreamDecoder(reader io.Reader) *JSONStreamDecoder {
+	return &JSONStreamDecoder{
+		reader:   bufio.NewReader(reader),
+		lossless: true,
+	}
+}
+
+// JSONStreamDecoder decodes objects one by one from the reader,
+// so a large batch of messages can be parsed with bounded memory
+//
+//	decoder := NewJSONStreamDecoder(file)
+//	for {
+//	    info, err := decoder.Next()
+//	    if err == io.EOF {
+//	        break
+//	    } else if err != nil {
+//	        return err
+//	    }
+//	    msg := ParseReliableMessage(info)
+//	    ...
+//	}
+type JSONStreamDecoder struct {
+	reader   *bufio.Reader
+	decoder  *json.Decoder
+	lossless bool
+
+	// array is true when the stream is a JSON array
+	array bool
+	// done is true after the end of the array
+	done bool
+}
+
+// Next returns the next object in the stream
+//
+// Returns: io.EOF at the end of stream
+func (stream *JSONStreamDecoder) Next() (StringKeyMap, error) {
+	if stream.decoder == nil {
+		if err := stream.start(); err != nil {
+			return nil, err
+		}
+	}
+	if stream.done {
+		return nil, io.EOF
+	}
+	decoder := stream.decoder
+	if stream.array && !decoder.More() {
+		// end of array: ']'
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		stream.done = true
+		return nil, io.EOF
+	}
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		if err == io.EOF && stream.array {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	dict, ok := value.(map[string]any)
+	if !ok {
+		return nil, ErrJSONStream
+	} else if stream.lossless {
+		exactNumbers(dict)
+	}
+	return dict, nil
+}
+
+func (stream *JSONStreamDecoder) start() error {
+	// skip whitespaces (and UTF-8 BOM) to check the first character
+	for {
+		ch, _, err := stream.reader.ReadRune()
+		if err != nil {
+			return err
+		}
+		switch ch {
+		case ' ', '\t', '\r', '\n', '\uFEFF':
+			continue
+		}
+		if err = stream.reader.UnreadRune(); err != nil {
+			return err
+		}
+		stream.array = ch == '['
+		break
+	}
+	decoder := json.NewDecoder(stream.reader)
+	if stream.lossless {
+		decoder.UseNumber()
+	}
+	if stream.array {
+		// start of array: '['
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+	}
+	stream.decoder = decoder
+	return nil
+}
+
+// NewJSONStreamEncoder creates encoder to write objects as JSON array,
+// or JSON Lines when 'lines' is true
+func NewJSONStreamEncoder(writer io.Writer, lines bool) *JSONStreamEncoder {
+	return &JSONStreamEncoder{
+		writer: writer,
+		lines:  lines,
+	}
+}
+
+// JSONStreamEncoder writes objects one by one,
+// the JSON array must be finished with Close()
+type JSONStreamEncoder struct {
+	writer io.Writer
+	lines  bool
+	count  int
+}
+
+// Write appends the object into the stream
+func (stream *JSONStreamEncoder) Write(info StringKeyMap) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	var sep string
+	if stream.lines {
+		sep = ""
+		data = append(data, '\n')
+	} else if stream.count == 0 {
+		sep = "[\n"
+	} else {
+		sep = ",\n"
+	}
+	if _, err = io.WriteString(stream.writer, sep); err != nil {
+		return err
+	} else if _, err = stream.writer.Write(data); err != nil {
+		return err
+	}
+	stream.count++
+	return nil
+}
+
+// Close finishes the JSON array (the writer is not closed)
+func (stream *JSONStreamEncoder) Close() error {
+	if stream.lines {
+		return nil
+	} else if stream.count == 0 {
+		_, err := io.WriteString(stream.writer, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(stream.writer, "\n]\n")
+	return err
+}
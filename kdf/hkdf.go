@@ -0,0 +1,135 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package kdf
+
+import (
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/digest"
+)
+
+// HKDFExtract extracts a pseudorandom key from the input keying material
+//
+//	PRK = HMAC-Hash(salt, IKM)
+//
+// Returns: nil if the digest algorithm is not supported
+func HKDFExtract(algorithm string, salt, ikm []byte) []byte {
+	if len(salt) == 0 {
+		// zero-filled salt with the hash length
+		digester := GetDigester(algorithm)
+		if digester == nil {
+			//panic("digest algorithm not supported: " + algorithm)
+			return nil
+		}
+		salt = make([]byte, len(digester.Digest(nil)))
+	}
+	return HMAC(algorithm, salt, ikm)
+}
+
+// HKDFExpand expands the pseudorandom key to the output keying material
+//
+//	T(0) = empty
+//	T(i) = HMAC-Hash(PRK, T(i-1) | info | i)
+//	OKM  = first L bytes of T(1) | T(2) | ...
+//
+// Returns: nil if the digest algorithm is not supported, or size too large
+func HKDFExpand(algorithm string, prk, info []byte, size int) []byte {
+	mac := newHMAC(algorithm, prk)
+	if mac == nil || size < 0 {
+		return nil
+	}
+	okm := make([]byte, 0, size)
+	var block []byte
+	var counter int
+	for len(okm) < size {
+		counter++
+		if counter > 255 {
+			//panic("HKDF output too long")
+			return nil
+		}
+		block = mac.sum(block, info, []byte{byte(counter)})
+		okm = append(okm, block...)
+	}
+	return okm[:size]
+}
+
+// HKDFDerive runs HKDF (RFC 5869) extract & expand
+func HKDFDerive(algorithm string, secret, salt, info []byte, size int) []byte {
+	prk := HKDFExtract(algorithm, salt, secret)
+	if prk == nil {
+		return nil
+	}
+	return HKDFExpand(algorithm, prk, info, size)
+}
+
+func NewHKDF(digest string, salt, info []byte) KeyDerivationFunction {
+	dict := NewMap()
+	dict["algorithm"] = HKDF
+	dict["digest"] = digest
+	if len(salt) > 0 {
+		dict["salt"] = encodeParam(salt)
+	}
+	if len(info) > 0 {
+		dict["info"] = encodeParam(info)
+	}
+	return NewHKDFWithMap(dict)
+}
+
+func NewHKDFWithMap(dict StringKeyMap) KeyDerivationFunction {
+	return &HKDFFunction{
+		Dictionary: NewDictionary(dict),
+	}
+}
+
+// HKDFFunction implements HMAC-based Extract-and-Expand Key Derivation Function
+//
+//	KDF Info JSON Format: {
+//	    "algorithm" : "HKDF",
+//	    "digest"    : "SHA-256",   // Optional: default is "SHA-256"
+//	    "salt"      : "{BASE64}",  // Optional: default is zero-filled
+//	    "info"      : "{BASE64}"   // Optional: context info
+//	}
+type HKDFFunction struct {
+	//KeyDerivationFunction
+	*Dictionary
+}
+
+// Override
+func (kdf *HKDFFunction) Algorithm() string {
+	return kdf.GetString("algorithm", HKDF)
+}
+
+// Override
+func (kdf *HKDFFunction) DeriveKey(secret []byte, size int) []byte {
+	digest := kdf.GetString("digest", "SHA-256")
+	salt := decodeParam(kdf.Get("salt"))
+	info := decodeParam(kdf.Get("info"))
+	return HKDFDerive(digest, secret, salt, info, size)
+}
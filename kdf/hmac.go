@@ -0,0 +1,141 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package kdf
+
+import (
+	"crypto/hmac"
+	"hash"
+
+	. "github.com/dimchat/mkm-go/digest"
+	. "github.com/dimchat/plugins-go/digest"
+)
+
+// block sizes (in bytes) of the hash functions, for HMAC key padding
+// with digesters that cannot stream
+var digestBlockSizes = map[string]int{
+	"MD5":        64,
+	"SHA1":       64,
+	"SHA224":     64,
+	"SHA256":     64,
+	"RIPEMD160":  64,
+	"SHA384":     128,
+	"SHA512":     128,
+	"BLAKE2B":    128,
+	"BLAKE2B256": 128,
+	"BLAKE2B512": 128,
+	"SHA3256":    136,
+	"KECCAK256":  136,
+	"SHA3512":    72,
+}
+
+func digestBlockSize(algorithm string) int {
+	size, ok := digestBlockSizes[DigestAlgorithm(algorithm)]
+	if !ok {
+		size = 64
+	}
+	return size
+}
+
+// HMAC computes the keyed-hash message authentication code (RFC 2104)
+// with the digester registered for the algorithm name
+//
+// Returns: nil if the digest algorithm is not supported
+func HMAC(algorithm string, key, data []byte) []byte {
+	mac := newHMAC(algorithm, key)
+	if mac == nil {
+		return nil
+	}
+	return mac.sum(data)
+}
+
+type hmacContext struct {
+	// streaming digesters use the standard HMAC
+	mac hash.Hash
+
+	// one-shot digesters pad the key by themselves
+	digester MessageDigester
+	ipad     []byte
+	opad     []byte
+}
+
+func newHMAC(algorithm string, key []byte) *hmacContext {
+	digester := GetDigester(algorithm)
+	if digester == nil {
+		//panic("digest algorithm not supported: " + algorithm)
+		return nil
+	} else if sd, ok := digester.(StreamDigester); ok {
+		return &hmacContext{
+			mac: hmac.New(sd.New, key),
+		}
+	}
+	blockSize := digestBlockSize(algorithm)
+	if len(key) > blockSize {
+		key = digester.Digest(key)
+	}
+	ipad := make([]byte, blockSize)
+	opad := make([]byte, blockSize)
+	copy(ipad, key)
+	copy(opad, key)
+	for i := 0; i < blockSize; i++ {
+		ipad[i] ^= 0x36
+		opad[i] ^= 0x5c
+	}
+	return &hmacContext{
+		digester: digester,
+		ipad:     ipad,
+		opad:     opad,
+	}
+}
+
+// sum returns H(K ^ opad || H(K ^ ipad || parts...))
+func (mac *hmacContext) sum(parts ...[]byte) []byte {
+	if mac.mac != nil {
+		mac.mac.Reset()
+		for _, part := range parts {
+			mac.mac.Write(part)
+		}
+		return mac.mac.Sum(nil)
+	}
+	size := len(mac.ipad)
+	for _, part := range parts {
+		size += len(part)
+	}
+	inner := make([]byte, 0, size)
+	inner = append(inner, mac.ipad...)
+	for _, part := range parts {
+		inner = append(inner, part...)
+	}
+	hash := mac.digester.Digest(inner)
+	outer := make([]byte, 0, len(mac.opad)+len(hash))
+	outer = append(outer, mac.opad...)
+	outer = append(outer, hash...)
+	return mac.digester.Digest(outer)
+}
@@ -0,0 +1,138 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package kdf
+
+import (
+	. "github.com/dimchat/core-go/format"
+	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/mkm-go/types"
+)
+
+//goland:noinspection GoSnakeCaseUsage
+const (
+	HKDF   = "HKDF"
+	PBKDF2 = "PBKDF2"
+	SCRYPT = "scrypt"
+)
+
+// KeyDerivationFunction derives key material from a secret
+// (shared secret, master key, password, ...)
+//
+// The parameters are kept in the dictionary, so a derived key can record
+// how it was made and be derived again later
+//
+//	KDF Info JSON Format: {
+//	    "algorithm" : "HKDF",      // "HKDF", "PBKDF2" or "scrypt"
+//	    "digest"    : "SHA-256",   // hash function for HKDF & PBKDF2
+//	    "salt"      : "{BASE64}",  // Optional
+//	    ...
+//	}
+type KeyDerivationFunction interface {
+	Mapper
+
+	// Algorithm returns the KDF name
+	Algorithm() string
+
+	// DeriveKey derives key material with the given length (in bytes)
+	//
+	// Returns: nil on invalid parameters
+	DeriveKey(secret []byte, size int) []byte
+}
+
+// KeyDerivationFunctionFactory parses KDF from the parameters dictionary
+type KeyDerivationFunctionFactory interface {
+
+	// ParseKeyDerivationFunction creates KDF with the parameters
+	//
+	// Returns: nil on invalid parameters
+	ParseKeyDerivationFunction(info StringKeyMap) KeyDerivationFunction
+}
+
+//
+//  KDF Factory Registry
+//
+
+var sharedKDFFactories = make(map[string]KeyDerivationFunctionFactory, 4)
+
+// SetKeyDerivationFunctionFactory registers a KDF factory for the algorithm name
+func SetKeyDerivationFunctionFactory(algorithm string, factory KeyDerivationFunctionFactory) {
+	sharedKDFFactories[algorithm] = factory
+}
+
+// GetKeyDerivationFunctionFactory returns the KDF factory for the algorithm name
+func GetKeyDerivationFunctionFactory(algorithm string) KeyDerivationFunctionFactory {
+	return sharedKDFFactories[algorithm]
+}
+
+// ParseKeyDerivationFunction creates KDF from the parameters dictionary,
+// the factory is selected by the "algorithm" field
+//
+// Returns: nil on unknown algorithm or invalid parameters
+func ParseKeyDerivationFunction(info any) KeyDerivationFunction {
+	if info == nil {
+		return nil
+	} else if kdf, ok := info.(KeyDerivationFunction); ok {
+		return kdf
+	}
+	dict := FetchMap(info)
+	if dict == nil {
+		//panic("KDF info error")
+		return nil
+	}
+	algorithm := ConvertString(dict["algorithm"], "")
+	factory := GetKeyDerivationFunctionFactory(algorithm)
+	if factory == nil {
+		//panic("KDF not supported: " + algorithm)
+		return nil
+	}
+	return factory.ParseKeyDerivationFunction(dict)
+}
+
+//
+//  Parameters
+//
+
+// encodeParam encodes binary parameter (salt, info) as Base64 string
+func encodeParam(data []byte) any {
+	ted := NewBase64DataWithBytes(data)
+	return ted.Serialize()
+}
+
+func decodeParam(value any) []byte {
+	if value == nil {
+		return nil
+	}
+	ted := ParseTransportableData(value)
+	if ted == nil {
+		return nil
+	}
+	return ted.Bytes()
+}
@@ -0,0 +1,181 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package kdf_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/dimchat/plugins-go/ext"
+	. "github.com/dimchat/plugins-go/kdf"
+)
+
+func init() {
+	ext.ExtensionLoader{}.Load()
+	ext.PluginLoader{}.Load()
+}
+
+func unhex(t *testing.T, text string) []byte {
+	bin, err := hex.DecodeString(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bin
+}
+
+// RFC 5869, Appendix A
+func TestHKDF(t *testing.T) {
+	vectors := []struct {
+		digest string
+		ikm    string
+		salt   string
+		info   string
+		prk    string
+		okm    string
+	}{
+		{ // Test Case 1
+			"SHA-256",
+			"0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+			"000102030405060708090a0b0c",
+			"f0f1f2f3f4f5f6f7f8f9",
+			"077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5",
+			"3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+		},
+		{ // Test Case 3
+			"SHA-256",
+			"0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b",
+			"",
+			"",
+			"19ef24a32c717b167f33a91d6f648bdf96596776afdb6377ac434c1c293ccb04",
+			"8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+		},
+		{ // Test Case 4
+			"SHA-1",
+			"0b0b0b0b0b0b0b0b0b0b0b",
+			"000102030405060708090a0b0c",
+			"f0f1f2f3f4f5f6f7f8f9",
+			"9b6c18c432a7bf8f0e71c8eb88f4b30baa2ba243",
+			"085a01ea1b10f36933068b56efa5ad81a4f14b822f5b091568a9cdd4f155fda2c22e422478d305f3f896",
+		},
+		{ // Test Case 7, salt not provided
+			"SHA-1",
+			"0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c",
+			"",
+			"",
+			"2adccada18779e7c2077ad2eb19d3f3e731385dd",
+			"2c91117204d745f3500d636a62f64f0ab3bae548aa53d423b0d1f27ebba6f5e5673a081d70cce7acfc48",
+		},
+	}
+	for index, v := range vectors {
+		ikm, salt, info := unhex(t, v.ikm), unhex(t, v.salt), unhex(t, v.info)
+		okm := unhex(t, v.okm)
+		if prk := HKDFExtract(v.digest, salt, ikm); !bytes.Equal(prk, unhex(t, v.prk)) {
+			t.Errorf("#%d: PRK = %x, want %s", index, prk, v.prk)
+		}
+		if out := HKDFDerive(v.digest, ikm, salt, info, len(okm)); !bytes.Equal(out, okm) {
+			t.Errorf("#%d: OKM = %x, want %s", index, out, v.okm)
+		}
+		// derive again with the parsed function
+		kdf := ParseKeyDerivationFunction(NewHKDF(v.digest, salt, info).Map())
+		if out := kdf.DeriveKey(ikm, len(okm)); !bytes.Equal(out, okm) {
+			t.Errorf("#%d: parsed HKDF = %x, want %s", index, out, v.okm)
+		}
+	}
+	// L > 255 * HashLen
+	if HKDFDerive("SHA-256", []byte("secret"), nil, nil, 255*32+1) != nil {
+		t.Errorf("HKDF output too long")
+	}
+}
+
+// RFC 6070 (PBKDF2-HMAC-SHA1), RFC 7914 section 11 (PBKDF2-HMAC-SHA256)
+func TestPBKDF2(t *testing.T) {
+	vectors := []struct {
+		digest     string
+		password   string
+		salt       string
+		iterations int
+		dk         string
+	}{
+		{"SHA-1", "password", "salt", 1, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+		{"SHA-1", "password", "salt", 2, "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"},
+		{"SHA-1", "password", "salt", 4096, "4b007901b765489abead49d926f721d065a429c1"},
+		{"SHA-1", "passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096,
+			"3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038"},
+		{"SHA-1", "pass\x00word", "sa\x00lt", 4096, "56fa6aa75548099dcc37d7f03425e0c3"},
+		{"SHA-256", "passwd", "salt", 1,
+			"55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc" +
+				"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+	}
+	for index, v := range vectors {
+		dk := unhex(t, v.dk)
+		out := PBKDF2Derive(v.digest, []byte(v.password), []byte(v.salt), v.iterations, len(dk))
+		if !bytes.Equal(out, dk) {
+			t.Errorf("#%d: DK = %x, want %s", index, out, v.dk)
+		}
+		kdf := ParseKeyDerivationFunction(NewPBKDF2(v.digest, []byte(v.salt), v.iterations).Map())
+		if out = kdf.DeriveKey([]byte(v.password), len(dk)); !bytes.Equal(out, dk) {
+			t.Errorf("#%d: parsed PBKDF2 = %x, want %s", index, out, v.dk)
+		}
+	}
+}
+
+// RFC 7914, section 12
+func TestScrypt(t *testing.T) {
+	vectors := []struct {
+		password string
+		salt     string
+		n, r, p  int
+		dk       string
+	}{
+		{"", "", 16, 1, 1,
+			"77d6576238657b203b19ca42c18a0497f16b4844e3074ae8dfdffa3fede21442" +
+				"fcd0069ded0948f8326a753a0fc81f17e8d3e0fb2e0d3628cf35e20c38d18906"},
+		{"password", "NaCl", 1024, 8, 16,
+			"fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b373162" +
+				"2eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640"},
+		{"pleaseletmein", "SodiumChloride", 16384, 8, 1,
+			"7023bdcb3afd7348461c06cd81fd38ebfda8fbba904f8e3ea9b543f6545da1f2" +
+				"d5432955613f0fcf62d49705242a9af9e61e85dc0d651e40dfcf017b45575887"},
+	}
+	for index, v := range vectors {
+		dk := unhex(t, v.dk)
+		out := ScryptDerive([]byte(v.password), []byte(v.salt), v.n, v.r, v.p, len(dk))
+		if !bytes.Equal(out, dk) {
+			t.Errorf("#%d: DK = %x, want %s", index, out, v.dk)
+		}
+	}
+	kdf := ParseKeyDerivationFunction(NewScrypt([]byte("NaCl"), 1024, 8, 16).Map())
+	if out := kdf.DeriveKey([]byte("password"), 64); !bytes.Equal(out, unhex(t, vectors[1].dk)) {
+		t.Errorf("parsed scrypt = %x", out)
+	}
+	// N must be a power of 2 greater than 1
+	for _, n := range []int{0, 1, 15} {
+		if ScryptDerive([]byte("password"), nil, n, 1, 1, 32) != nil {
+			t.Errorf("scrypt accepted N = %d", n)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package kdf
+
+import (
+	. "github.com/dimchat/mkm-go/types"
+)
+
+// PBKDF2Derive derives key from the password (RFC 8018)
+//
+//	DK = T(1) | T(2) | ...
+//	T(i) = U(1) ^ U(2) ^ ... ^ U(c)
+//	U(1) = PRF(P, S | INT(i))
+//	U(j) = PRF(P, U(j-1))
+//
+// Returns: nil if the digest algorithm is not supported
+func PBKDF2Derive(algorithm string, password, salt []byte, iterations, size int) []byte {
+	prf := newHMAC(algorithm, password)
+	if prf == nil || iterations < 1 || size < 0 {
+		return nil
+	}
+	dk := make([]byte, 0, size)
+	index := make([]byte, 4)
+	var counter uint32
+	for len(dk) < size {
+		counter++
+		index[0] = byte(counter >> 24)
+		index[1] = byte(counter >> 16)
+		index[2] = byte(counter >> 8)
+		index[3] = byte(counter)
+		u := prf.sum(salt, index)
+		t := make([]byte, len(u))
+		copy(t, u)
+		for n := 1; n < iterations; n++ {
+			u = prf.sum(u)
+			for i := range t {
+				t[i] ^= u[i]
+			}
+		}
+		dk = append(dk, t...)
+	}
+	return dk[:size]
+}
+
+func NewPBKDF2(digest string, salt []byte, iterations int) KeyDerivationFunction {
+	dict := NewMap()
+	dict["algorithm"] = PBKDF2
+	dict["digest"] = digest
+	dict["salt"] = encodeParam(salt)
+	dict["iterations"] = iterations
+	return NewPBKDF2WithMap(dict)
+}
+
+func NewPBKDF2WithMap(dict StringKeyMap) KeyDerivationFunction {
+	return &PBKDF2Function{
+		Dictionary: NewDictionary(dict),
+	}
+}
+
+// PBKDF2Function implements Password-Based Key Derivation Function 2
+//
+//	KDF Info JSON Format: {
+//	    "algorithm"  : "PBKDF2",
+//	    "digest"     : "SHA-256",   // Optional: default is "SHA-256"
+//	    "salt"       : "{BASE64}",
+//	    "iterations" : 100000
+//	}
+type PBKDF2Function struct {
+	//KeyDerivationFunction
+	*Dictionary
+}
+
+// Override
+func (kdf *PBKDF2Function) Algorithm() string {
+	return kdf.GetString("algorithm", PBKDF2)
+}
+
+// Override
+func (kdf *PBKDF2Function) DeriveKey(secret []byte, size int) []byte {
+	digest := kdf.GetString("digest", "SHA-256")
+	salt := decodeParam(kdf.Get("salt"))
+	iterations := kdf.GetInt("iterations", 0)
+	return PBKDF2Derive(digest, secret, salt, iterations, size)
+}
@@ -0,0 +1,201 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package kdf
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	. "github.com/dimchat/mkm-go/types"
+)
+
+// ScryptDerive derives key from the password with the memory-hard function (RFC 7914)
+//
+//	B  = PBKDF2-HMAC-SHA256(P, S, 1, p * 128 * r)
+//	B' = ROMix(B[i], N) for each block
+//	DK = PBKDF2-HMAC-SHA256(P, B', 1, dkLen)
+//
+// Parameters:
+//   - cost        - CPU/memory cost N, must be a power of 2 greater than 1
+//   - blockSize   - block size r
+//   - parallelism - parallelization p
+//
+// Returns: nil on invalid parameters
+func ScryptDerive(password, salt []byte, cost, blockSize, parallelism, size int) []byte {
+	n, r, p := cost, blockSize, parallelism
+	if n <= 1 || n&(n-1) != 0 || r <= 0 || p <= 0 || size < 0 {
+		//panic("scrypt parameters error")
+		return nil
+	} else if uint64(r)*uint64(p) >= 1<<30 || r > maxInt/128/p || r > maxInt/256 || n > maxInt/128/r {
+		//panic("scrypt parameters too large")
+		return nil
+	}
+	b := PBKDF2Derive("SHA-256", password, salt, 1, p*128*r)
+	if b == nil {
+		return nil
+	}
+	xy := make([]uint32, 64*r)
+	v := make([]uint32, 32*n*r)
+	for i := 0; i < p; i++ {
+		scryptROMix(b[i*128*r:], r, n, v, xy)
+	}
+	return PBKDF2Derive("SHA-256", password, b, 1, size)
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+func scryptROMix(b []byte, r, n int, v, xy []uint32) {
+	tmp := make([]uint32, 16)
+	R := 32 * r
+	x := xy
+	y := xy[R:]
+	for i := 0; i < R; i++ {
+		x[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	for i := 0; i < n; i++ {
+		copy(v[i*R:], x)
+		scryptBlockMix(tmp, x, y, r)
+		x, y = y, x
+	}
+	for i := 0; i < n; i++ {
+		j := int(x[(2*r-1)*16] & uint32(n-1))
+		for k := 0; k < R; k++ {
+			x[k] ^= v[j*R+k]
+		}
+		scryptBlockMix(tmp, x, y, r)
+		x, y = y, x
+	}
+	for i := 0; i < R; i++ {
+		binary.LittleEndian.PutUint32(b[i*4:], x[i])
+	}
+}
+
+// scryptBlockMix mixes the input block with Salsa20/8, output to out
+func scryptBlockMix(tmp, in, out []uint32, r int) {
+	copy(tmp, in[(2*r-1)*16:])
+	for i := 0; i < 2*r; i++ {
+		for k := 0; k < 16; k++ {
+			tmp[k] ^= in[i*16+k]
+		}
+		salsa208(tmp)
+		// even blocks to the first half, odd blocks to the second half
+		offset := (i/2)*16 + (i%2)*r*16
+		copy(out[offset:], tmp)
+	}
+}
+
+func salsa208(b []uint32) {
+	var x [16]uint32
+	copy(x[:], b)
+	for i := 0; i < 8; i += 2 {
+		// columns
+		x[4] ^= bits.RotateLeft32(x[0]+x[12], 7)
+		x[8] ^= bits.RotateLeft32(x[4]+x[0], 9)
+		x[12] ^= bits.RotateLeft32(x[8]+x[4], 13)
+		x[0] ^= bits.RotateLeft32(x[12]+x[8], 18)
+		x[9] ^= bits.RotateLeft32(x[5]+x[1], 7)
+		x[13] ^= bits.RotateLeft32(x[9]+x[5], 9)
+		x[1] ^= bits.RotateLeft32(x[13]+x[9], 13)
+		x[5] ^= bits.RotateLeft32(x[1]+x[13], 18)
+		x[14] ^= bits.RotateLeft32(x[10]+x[6], 7)
+		x[2] ^= bits.RotateLeft32(x[14]+x[10], 9)
+		x[6] ^= bits.RotateLeft32(x[2]+x[14], 13)
+		x[10] ^= bits.RotateLeft32(x[6]+x[2], 18)
+		x[3] ^= bits.RotateLeft32(x[15]+x[11], 7)
+		x[7] ^= bits.RotateLeft32(x[3]+x[15], 9)
+		x[11] ^= bits.RotateLeft32(x[7]+x[3], 13)
+		x[15] ^= bits.RotateLeft32(x[11]+x[7], 18)
+		// rows
+		x[1] ^= bits.RotateLeft32(x[0]+x[3], 7)
+		x[2] ^= bits.RotateLeft32(x[1]+x[0], 9)
+		x[3] ^= bits.RotateLeft32(x[2]+x[1], 13)
+		x[0] ^= bits.RotateLeft32(x[3]+x[2], 18)
+		x[6] ^= bits.RotateLeft32(x[5]+x[4], 7)
+		x[7] ^= bits.RotateLeft32(x[6]+x[5], 9)
+		x[4] ^= bits.RotateLeft32(x[7]+x[6], 13)
+		x[5] ^= bits.RotateLeft32(x[4]+x[7], 18)
+		x[11] ^= bits.RotateLeft32(x[10]+x[9], 7)
+		x[8] ^= bits.RotateLeft32(x[11]+x[10], 9)
+		x[9] ^= bits.RotateLeft32(x[8]+x[11], 13)
+		x[10] ^= bits.RotateLeft32(x[9]+x[8], 18)
+		x[12] ^= bits.RotateLeft32(x[15]+x[14], 7)
+		x[13] ^= bits.RotateLeft32(x[12]+x[15], 9)
+		x[14] ^= bits.RotateLeft32(x[13]+x[12], 13)
+		x[15] ^= bits.RotateLeft32(x[14]+x[13], 18)
+	}
+	for i := range x {
+		b[i] += x[i]
+	}
+}
+
+func NewScrypt(salt []byte, cost, blockSize, parallelism int) KeyDerivationFunction {
+	dict := NewMap()
+	dict["algorithm"] = SCRYPT
+	dict["salt"] = encodeParam(salt)
+	dict["N"] = cost
+	dict["r"] = blockSize
+	dict["p"] = parallelism
+	return NewScryptWithMap(dict)
+}
+
+func NewScryptWithMap(dict StringKeyMap) KeyDerivationFunction {
+	return &ScryptFunction{
+		Dictionary: NewDictionary(dict),
+	}
+}
+
+// ScryptFunction implements the scrypt password-based key derivation function
+//
+//	KDF Info JSON Format: {
+//	    "algorithm" : "scrypt",
+//	    "salt"      : "{BASE64}",
+//	    "N"         : 32768,  // CPU/memory cost
+//	    "r"         : 8,      // block size
+//	    "p"         : 1       // parallelization
+//	}
+type ScryptFunction struct {
+	//KeyDerivationFunction
+	*Dictionary
+}
+
+// Override
+func (kdf *ScryptFunction) Algorithm() string {
+	return kdf.GetString("algorithm", SCRYPT)
+}
+
+// Override
+func (kdf *ScryptFunction) DeriveKey(secret []byte, size int) []byte {
+	salt := decodeParam(kdf.Get("salt"))
+	n := kdf.GetInt("N", 0)
+	r := kdf.GetInt("r", 8)
+	p := kdf.GetInt("p", 1)
+	return ScryptDerive(secret, salt, n, r, p, size)
+}
@@ -0,0 +1,164 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+// MaxImagePixels limits the image size to decode for thumbnail (decompression bomb)
+var MaxImagePixels = 40 * 1024 * 1024
+
+// ImageSize returns the width & height of the image data,
+// supports JPEG, PNG, GIF, WebP & BMP
+//
+// Returns: false on unknown format
+func ImageSize(data []byte) (width, height int, ok bool) {
+	if config, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		return config.Width, config.Height, true
+	}
+	switch DetectContentType(data) {
+	case "image/webp":
+		return webpSize(data)
+	case "image/bmp":
+		return bmpSize(data)
+	}
+	return 0, 0, false
+}
+
+func webpSize(data []byte) (int, int, bool) {
+	switch {
+	case hasPrefix(data, 12, "VP8 ") && len(data) >= 30:
+		// lossy: 14 bits each, after the start code "\x9D\x01\x2A"
+		w := int(binary.LittleEndian.Uint16(data[26:]) & 0x3FFF)
+		h := int(binary.LittleEndian.Uint16(data[28:]) & 0x3FFF)
+		return w, h, true
+	case hasPrefix(data, 12, "VP8L") && len(data) >= 25:
+		// lossless: 14 bits each, after the signature 0x2F
+		b := data[21:25]
+		w := 1 + (int(b[1]&0x3F)<<8 | int(b[0]))
+		h := 1 + (int(b[3]&0x0F)<<10 | int(b[2])<<2 | int(b[1]&0xC0)>>6)
+		return w, h, true
+	case hasPrefix(data, 12, "VP8X") && len(data) >= 30:
+		// extended: 24 bits each (minus one)
+		w := 1 + (int(data[24]) | int(data[25])<<8 | int(data[26])<<16)
+		h := 1 + (int(data[27]) | int(data[28])<<8 | int(data[29])<<16)
+		return w, h, true
+	}
+	return 0, 0, false
+}
+
+func bmpSize(data []byte) (int, int, bool) {
+	if len(data) < 26 || !hasPrefix(data, 6, "\x00\x00\x00\x00") {
+		return 0, 0, false
+	}
+	w := int32(binary.LittleEndian.Uint32(data[18:]))
+	h := int32(binary.LittleEndian.Uint32(data[22:]))
+	if h < 0 {
+		// top-down bitmap
+		h = -h
+	}
+	return int(w), int(h), w > 0 && h > 0
+}
+
+// Thumbnail scales the image down to fit in 'maxSide' pixels,
+// and encodes it as JPEG; supports JPEG, PNG & GIF
+//
+// Returns: nil on unknown format or the image is too large
+func Thumbnail(data []byte, maxSide int, quality int) []byte {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 {
+		return nil
+	} else if config.Width*config.Height > MaxImagePixels {
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	thumb := scaleImage(img, maxSide)
+	buf := &bytes.Buffer{}
+	if err = jpeg.Encode(buf, thumb, &jpeg.Options{Quality: quality}); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// scaleImage scales the image to fit in the box with area averaging,
+// transparent pixels are blended on white
+func scaleImage(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	dw, dh := sw, sh
+	if sw > maxSide || sh > maxSide {
+		if sw >= sh {
+			dw, dh = maxSide, max(1, sh*maxSide/sw)
+		} else {
+			dw, dh = max(1, sw*maxSide/sh), maxSide
+		}
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+			// sample up to 4x4 pixels in the box
+			stepX, stepY := max(1, (x1-x0)/4), max(1, (y1-y0)/4)
+			var r, g, b, n uint32
+			for sy := y0; sy < y1; sy += stepY {
+				for sx := x0; sx < x1; sx += stepX {
+					cr, cg, cb, ca := src.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					// blend on white
+					white := 0xFFFF - ca
+					r += (cr + white) >> 8
+					g += (cg + white) >> 8
+					b += (cb + white) >> 8
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 0xFF})
+		}
+	}
+	return dst
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,94 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package media
+
+import (
+	"strings"
+
+	. "github.com/dimchat/core-go/format"
+	. "github.com/dimchat/mkm-go/protocol"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/format"
+)
+
+const (
+	// ThumbnailSize is the max width/height of thumbnail
+	ThumbnailSize = 128
+
+	// ThumbnailQuality is the JPEG quality of thumbnail
+	ThumbnailQuality = 50
+)
+
+// FillFileInfo sniffs the file data, and fills the standard fields into PNF
+//
+//	PNF JSON Format: {
+//	    "URL"       : "https://...",  // or "data" : "..."
+//	    "filename"  : "photo.png",
+//	    "size"      : 123456,         // file size in bytes
+//	    "mimeType"  : "image/png",
+//	    "width"     : 1920,           // images only
+//	    "height"    : 1080,
+//	    "thumbnail" : "data:image/jpeg;base64,..."
+//	}
+func FillFileInfo(file Mapper, data []byte) {
+	file.Set("size", len(data))
+	mimeType := DetectContentType(data)
+	file.Set("mimeType", mimeType)
+	if !IsImage(mimeType) {
+		return
+	}
+	// image size
+	if width, height, ok := ImageSize(data); ok {
+		file.Set("width", width)
+		file.Set("height", height)
+	}
+	// thumbnail
+	if jpeg := Thumbnail(data, ThumbnailSize, ThumbnailQuality); jpeg != nil {
+		ted := NewEmbedDataWithEncoding("image/jpeg", BASE_64, jpeg)
+		if ted != nil {
+			file.Set("thumbnail", ted.Serialize())
+		}
+	}
+}
+
+// NewTransportableFileWithBytes creates PNF with the data embedded as data URI,
+// and fills the standard fields (size, MIME type, image width/height & thumbnail)
+func NewTransportableFileWithBytes(data []byte, filename string) TransportableFile {
+	// "text/plain; charset=utf-8" => "text/plain"
+	mimeType := DetectContentType(data)
+	if pos := strings.IndexByte(mimeType, ';'); pos > 0 {
+		mimeType = mimeType[:pos]
+	}
+	ted := NewEmbedDataWithEncoding(mimeType, BASE_64, data)
+	pnf := CreateTransportableFile(ted, filename, nil, nil)
+	FillFileInfo(pnf, data)
+	return pnf
+}
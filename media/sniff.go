@@ -0,0 +1,146 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package media
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// DetectContentType sniffs the MIME type of the file data,
+// common image, audio & video signatures are checked before
+// 'http.DetectContentType()', which knows only a few of them
+//
+// Returns: "application/octet-stream" for unknown data
+func DetectContentType(data []byte) string {
+	if mimeType := detectSignature(data); mimeType != "" {
+		return mimeType
+	}
+	return http.DetectContentType(data)
+}
+
+// IsImage checks whether the MIME type is "image/*"
+func IsImage(mimeType string) bool {
+	return len(mimeType) > 6 && mimeType[:6] == "image/"
+}
+
+func hasPrefix(data []byte, offset int, prefix string) bool {
+	end := offset + len(prefix)
+	return len(data) >= end && string(data[offset:end]) == prefix
+}
+
+func detectSignature(data []byte) string {
+	switch {
+	//
+	//  image/*
+	//
+	case hasPrefix(data, 0, "\xFF\xD8\xFF"):
+		return "image/jpeg"
+	case hasPrefix(data, 0, "\x89PNG\r\n\x1A\n"):
+		return "image/png"
+	case hasPrefix(data, 0, "GIF87a"), hasPrefix(data, 0, "GIF89a"):
+		return "image/gif"
+	case hasPrefix(data, 0, "RIFF") && hasPrefix(data, 8, "WEBP"):
+		return "image/webp"
+	case hasPrefix(data, 0, "BM") && hasPrefix(data, 6, "\x00\x00\x00\x00") && len(data) > 26:
+		return "image/bmp"
+	case hasPrefix(data, 0, "\x00\x00\x01\x00"):
+		return "image/x-icon"
+	case hasPrefix(data, 0, "II*\x00"), hasPrefix(data, 0, "MM\x00*"):
+		return "image/tiff"
+	//
+	//  audio/*
+	//
+	case hasPrefix(data, 0, "RIFF") && hasPrefix(data, 8, "WAVE"):
+		return "audio/wav"
+	case hasPrefix(data, 0, "ID3"):
+		return "audio/mpeg"
+	case hasPrefix(data, 0, "fLaC"):
+		return "audio/flac"
+	case hasPrefix(data, 0, "#!AMR"):
+		return "audio/amr"
+	case hasPrefix(data, 0, "OggS"):
+		if bytes.Contains(data[:min(len(data), 64)], []byte("\x01video")) ||
+			bytes.Contains(data[:min(len(data), 64)], []byte("theora")) {
+			return "video/ogg"
+		}
+		return "audio/ogg"
+	case len(data) > 2 && data[0] == 0xFF && data[1]&0xF6 == 0xF0:
+		// ADTS (AAC)
+		return "audio/aac"
+	case len(data) > 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0 && data[1]&0x06 != 0 && data[2]&0xF0 != 0xF0:
+		// MPEG audio frame sync (layer & bitrate are valid)
+		return "audio/mpeg"
+	//
+	//  video/*
+	//
+	case hasPrefix(data, 4, "ftyp"):
+		return detectFileType(data)
+	case hasPrefix(data, 0, "\x1A\x45\xDF\xA3"):
+		// EBML
+		if bytes.Contains(data[:min(len(data), 64)], []byte("webm")) {
+			return "video/webm"
+		}
+		return "video/x-matroska"
+	case hasPrefix(data, 0, "FLV"):
+		return "video/x-flv"
+	case hasPrefix(data, 0, "\x00\x00\x01\xBA"), hasPrefix(data, 0, "\x00\x00\x01\xB3"):
+		return "video/mpeg"
+	}
+	return ""
+}
+
+// detectFileType checks the brand of ISO base media file ("ftyp" box)
+func detectFileType(data []byte) string {
+	if len(data) < 12 {
+		return ""
+	}
+	switch string(data[8:12]) {
+	case "heic", "heix", "heim", "heis", "mif1", "msf1":
+		return "image/heic"
+	case "avif", "avis":
+		return "image/avif"
+	case "M4A ", "M4B ":
+		return "audio/mp4"
+	case "qt  ":
+		return "video/quicktime"
+	case "3gp4", "3gp5", "3gp6", "3gg6":
+		return "video/3gpp"
+	}
+	return "video/mp4"
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
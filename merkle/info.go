@@ -0,0 +1,210 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package merkle
+
+import (
+	"errors"
+
+	. "github.com/dimchat/core-go/format"
+	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/mkm-go/types"
+)
+
+var (
+	ErrAlgorithmNotSupported = errors.New("merkle: digest algorithm not supported")
+	ErrChunkSize             = errors.New("merkle: chunk size error")
+)
+
+func NewInfo(algorithm string, chunkSize int, size int64, count int, root []byte) *Info {
+	info := NewMap()
+	info["algorithm"] = algorithm
+	info["chunkSize"] = chunkSize
+	info["size"] = size
+	info["count"] = count
+	info["root"] = NewBase64DataWithBytes(root).Serialize()
+	return &Info{
+		Dictionary: NewDictionary(info),
+	}
+}
+
+// ParseInfo creates tree info from the dictionary
+//
+// Returns: nil on invalid info
+func ParseInfo(info any) *Info {
+	if info == nil {
+		return nil
+	} else if mi, ok := info.(*Info); ok {
+		return mi
+	}
+	dict := FetchMap(info)
+	if dict == nil {
+		return nil
+	}
+	mi := &Info{
+		Dictionary: NewDictionary(dict),
+	}
+	if mi.Algorithm() == "" || mi.ChunkSize() <= 0 || mi.Count() <= 0 || len(mi.Root()) == 0 {
+		//panic("merkle info error")
+		return nil
+	}
+	return mi
+}
+
+// Info describes the Merkle tree of a file
+//
+//	Merkle Info JSON Format: {
+//	    "algorithm" : "SHA-256",   // digest algorithm
+//	    "chunkSize" : 1048576,     // chunk size in bytes
+//	    "size"      : 12345678,    // file size in bytes
+//	    "count"     : 12,          // number of chunks
+//	    "root"      : "{BASE64}"   // root hash
+//	}
+type Info struct {
+	*Dictionary
+}
+
+func (info *Info) Algorithm() string {
+	return info.GetString("algorithm", "")
+}
+
+func (info *Info) ChunkSize() int {
+	return info.GetInt("chunkSize", 0)
+}
+
+func (info *Info) Size() int64 {
+	return info.GetInt64("size", 0)
+}
+
+func (info *Info) Count() int {
+	return info.GetInt("count", 0)
+}
+
+func (info *Info) Root() []byte {
+	return decodeBytes(info.Get("root"))
+}
+
+//
+//  Proof
+//
+
+// NewProof creates proof with the sibling hashes from the chunk up to the root
+func NewProof(index int, path [][]byte) *Proof {
+	var joined []byte
+	for _, hash := range path {
+		joined = append(joined, hash...)
+	}
+	info := NewMap()
+	info["index"] = index
+	info["path"] = NewBase64DataWithBytes(joined).Serialize()
+	return &Proof{
+		Dictionary: NewDictionary(info),
+	}
+}
+
+// ParseProof creates chunk proof from the dictionary
+//
+// Returns: nil on invalid proof
+func ParseProof(info any) *Proof {
+	if info == nil {
+		return nil
+	} else if proof, ok := info.(*Proof); ok {
+		return proof
+	}
+	dict := FetchMap(info)
+	if dict == nil {
+		return nil
+	}
+	proof := &Proof{
+		Dictionary: NewDictionary(dict),
+	}
+	if proof.Index() < 0 {
+		return nil
+	}
+	return proof
+}
+
+// Proof is the compact inclusion proof of one chunk
+//
+// The sides of the siblings are not stored, they are calculated from
+// the chunk index and count
+//
+//	Merkle Proof JSON Format: {
+//	    "index" : 3,          // chunk index
+//	    "path"  : "{BASE64}"  // sibling hashes joined, from leaf to root
+//	}
+type Proof struct {
+	*Dictionary
+}
+
+func (proof *Proof) Index() int {
+	return proof.GetInt("index", -1)
+}
+
+// Path splits the sibling hashes with the hash size
+//
+// Returns: nil if the path length not matched
+func (proof *Proof) Path(hashSize int) [][]byte {
+	joined := decodeBytes(proof.Get("path"))
+	if hashSize <= 0 || len(joined)%hashSize != 0 {
+		return nil
+	}
+	path := make([][]byte, 0, len(joined)/hashSize)
+	for i := 0; i < len(joined); i += hashSize {
+		path = append(path, joined[i:i+hashSize])
+	}
+	return path
+}
+
+//
+//  PNF
+//
+
+// SetFileInfo puts the tree info into the PNF dictionary ("merkle")
+func SetFileInfo(file Mapper, info *Info) {
+	if info == nil {
+		file.Remove("merkle")
+	} else {
+		file.Set("merkle", info.Map())
+	}
+}
+
+// GetFileInfo gets the tree info from the PNF dictionary ("merkle")
+//
+// Returns: nil if not found
+func GetFileInfo(file Mapper) *Info {
+	return ParseInfo(file.Get("merkle"))
+}
+
+func decodeBytes(value any) []byte {
+	if value == nil {
+		return nil
+	}
+	ted := ParseTransportableData(value)
+	if ted == nil {
+		return nil
+	}
+	return ted.Bytes()
+}
@@ -0,0 +1,204 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package merkle
+
+import (
+	"bytes"
+	"io"
+
+	. "github.com/dimchat/mkm-go/digest"
+	. "github.com/dimchat/plugins-go/digest"
+)
+
+// Tree is a Merkle tree over fixed-size chunks of a file
+//
+// Leaves and inner nodes use different prefixes, so a leaf can never be
+// taken for a node (second preimage):
+//
+//	leaf = H(0x00 | chunk)
+//	node = H(0x01 | left | right)
+//
+// The last node of a level without a sibling is promoted to the next level
+// unchanged, so the shape of the tree only depends on the chunk count
+type Tree struct {
+	algorithm string
+	digester  MessageDigester
+	chunkSize int
+	size      int64
+
+	// levels[0] are the leaves, the last level is the root
+	levels [][][]byte
+}
+
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// BuildTree reads all data from the reader, and builds the tree with the
+// digester registered for the algorithm name; only the chunk hashes are
+// kept in memory, not the data
+//
+// Returns: nil & error if the algorithm is not supported, or failed to read
+func BuildTree(algorithm string, reader io.Reader, chunkSize int) (*Tree, error) {
+	digester := GetDigester(algorithm)
+	if digester == nil {
+		return nil, ErrAlgorithmNotSupported
+	} else if chunkSize <= 0 {
+		return nil, ErrChunkSize
+	}
+	var leaves [][]byte
+	var size int64
+	buffer := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(reader, buffer)
+		if n > 0 || (len(leaves) == 0 && err == io.EOF) {
+			// an empty file has one empty chunk
+			leaves = append(leaves, leafHash(digester, buffer[:n]))
+			size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	tree := &Tree{
+		algorithm: algorithm,
+		digester:  digester,
+		chunkSize: chunkSize,
+		size:      size,
+	}
+	tree.build(leaves)
+	return tree, nil
+}
+
+func (tree *Tree) build(leaves [][]byte) {
+	levels := [][][]byte{leaves}
+	level := leaves
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, nodeHash(tree.digester, level[i], level[i+1]))
+			} else {
+				// promoted
+				next = append(next, level[i])
+			}
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	tree.levels = levels
+}
+
+// Root returns the root hash
+func (tree *Tree) Root() []byte {
+	top := tree.levels[len(tree.levels)-1]
+	return top[0]
+}
+
+// Count returns the number of chunks
+func (tree *Tree) Count() int {
+	return len(tree.levels[0])
+}
+
+// Info returns the tree info to be carried in a PNF dictionary
+func (tree *Tree) Info() *Info {
+	return NewInfo(tree.algorithm, tree.chunkSize, tree.size, tree.Count(), tree.Root())
+}
+
+// Proof returns the sibling hashes from the chunk up to the root
+//
+// Returns: nil if index out of range
+func (tree *Tree) Proof(index int) *Proof {
+	if index < 0 || index >= tree.Count() {
+		return nil
+	}
+	var path [][]byte
+	idx := index
+	for _, level := range tree.levels[:len(tree.levels)-1] {
+		if idx%2 == 1 {
+			path = append(path, level[idx-1])
+		} else if idx+1 < len(level) {
+			path = append(path, level[idx+1])
+		}
+		idx /= 2
+	}
+	return NewProof(index, path)
+}
+
+//
+//  Hashing
+//
+
+func leafHash(digester MessageDigester, chunk []byte) []byte {
+	return digestParts(digester, []byte{leafPrefix}, chunk)
+}
+
+func nodeHash(digester MessageDigester, left, right []byte) []byte {
+	return digestParts(digester, []byte{nodePrefix}, left, right)
+}
+
+func digestParts(digester MessageDigester, parts ...[]byte) []byte {
+	if sd, ok := digester.(StreamDigester); ok {
+		hash := sd.New()
+		for _, part := range parts {
+			hash.Write(part)
+		}
+		return hash.Sum(nil)
+	}
+	return digester.Digest(bytes.Join(parts, nil))
+}
+
+// rootFromProof calculates the root from the chunk and its sibling hashes
+//
+// Returns: nil if the path length not matched
+func rootFromProof(digester MessageDigester, index, count int, chunk []byte, path [][]byte) []byte {
+	hash := leafHash(digester, chunk)
+	idx, n, p := index, count, 0
+	for n > 1 {
+		if idx%2 == 1 {
+			if p >= len(path) {
+				return nil
+			}
+			hash = nodeHash(digester, path[p], hash)
+			p++
+		} else if idx+1 < n {
+			if p >= len(path) {
+				return nil
+			}
+			hash = nodeHash(digester, hash, path[p])
+			p++
+		}
+		idx /= 2
+		n = (n + 1) / 2
+	}
+	if p != len(path) {
+		return nil
+	}
+	return hash
+}
@@ -0,0 +1,121 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package merkle
+
+import (
+	"bytes"
+
+	. "github.com/dimchat/mkm-go/digest"
+	. "github.com/dimchat/plugins-go/digest"
+)
+
+// Verifier checks the chunks of a file against the tree info,
+// chunks can be verified in any order, so partial transfers can be resumed
+type Verifier struct {
+	info     *Info
+	digester MessageDigester
+	hashSize int
+
+	// indexes of verified chunks
+	verified map[int]bool
+}
+
+// NewVerifier creates verifier with the tree info from the PNF
+//
+// Returns: nil if the digest algorithm not supported
+func NewVerifier(info *Info) *Verifier {
+	if info == nil {
+		return nil
+	}
+	digester := GetDigester(info.Algorithm())
+	if digester == nil {
+		//panic("digest algorithm not supported")
+		return nil
+	}
+	return &Verifier{
+		info:     info,
+		digester: digester,
+		hashSize: len(info.Root()),
+		verified: make(map[int]bool, info.Count()),
+	}
+}
+
+// Verify checks the chunk with its proof, and marks it as verified
+func (verifier *Verifier) Verify(chunk []byte, proof *Proof) bool {
+	if proof == nil {
+		return false
+	}
+	info := verifier.info
+	index := proof.Index()
+	count := info.Count()
+	if index < 0 || index >= count {
+		return false
+	} else if !verifier.checkChunkSize(index, len(chunk)) {
+		return false
+	}
+	path := proof.Path(verifier.hashSize)
+	if path == nil && verifier.hashSize > 0 && count > 1 {
+		return false
+	}
+	root := rootFromProof(verifier.digester, index, count, chunk, path)
+	if root == nil || !bytes.Equal(root, info.Root()) {
+		return false
+	}
+	verifier.verified[index] = true
+	return true
+}
+
+// every chunk is full except the last one
+func (verifier *Verifier) checkChunkSize(index, size int) bool {
+	info := verifier.info
+	chunkSize := info.ChunkSize()
+	if index < info.Count()-1 {
+		return size == chunkSize
+	}
+	last := info.Size() - int64(index)*int64(chunkSize)
+	return int64(size) == last
+}
+
+// IsVerified checks whether the chunk was verified
+func (verifier *Verifier) IsVerified(index int) bool {
+	return verifier.verified[index]
+}
+
+// Missing returns indexes of the chunks not verified yet (to be resumed)
+func (verifier *Verifier) Missing() []int {
+	var missing []int
+	for i := 0; i < verifier.info.Count(); i++ {
+		if !verifier.verified[i] {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// IsComplete checks whether all chunks are verified
+func (verifier *Verifier) IsComplete() bool {
+	return len(verifier.verified) == verifier.info.Count()
+}
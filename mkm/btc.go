@@ -32,10 +32,9 @@ package mkm
 
 import (
 	. "github.com/dimchat/mkm-go/digest"
-	. "github.com/dimchat/mkm-go/format"
 	. "github.com/dimchat/mkm-go/protocol"
 	. "github.com/dimchat/mkm-go/types"
-	. "github.com/dimchat/plugins-go/types"
+	. "github.com/dimchat/plugins-go/format"
 )
 
 // -------------------------------------------------------------------------
@@ -93,17 +92,8 @@ func (address BTCAddress) Network() EntityType {
 func GenerateBTCAddress(fingerprint []byte, network EntityType) Address {
 	// 1. digest = ripemd160(sha256(fingerprint))
 	digest := RIPEMD160(SHA256(fingerprint))
-	// 2. head = network + digest
-	head := make([]byte, 21)
-	head[0] = uint8(network)
-	BytesCopy(digest, 0, head, 1, 20)
-	// 3. cc = sha256(sha256(head)).prefix(4)
-	cc := checkCode(head)
-	// 4. data = base58_encode(head + cc)
-	data := make([]byte, 25)
-	BytesCopy(head, 0, data, 0, 21)
-	BytesCopy(cc, 0, data, 21, 4)
-	base58 := Base58Encode(data)
+	// 2. address = base58check(network + digest)
+	base58 := EncodeBase58Check(uint8(network), digest)
 	return NewBTCAddress(base58, network)
 }
 
@@ -116,38 +106,15 @@ func GenerateBTCAddress(fingerprint []byte, network EntityType) Address {
 //
 // Returns: Valid Address (BTCAddress) if parsing succeeds, nil if invalid
 func ParseBTCAddress(base58 string) Address {
-	// decode
-	data := Base58Decode(base58)
-	if len(data) != 25 {
+	// decode & verify check code
+	version, digest, err := DecodeBase58Check(base58)
+	if err != nil {
+		//panic("address check code error")
+		return nil
+	} else if len(digest) != 20 {
 		//panic("address length error")
 		return nil
 	}
-	// CheckCode
-	prefix := make([]byte, 21)
-	suffix := make([]byte, 4)
-	BytesCopy(data, 0, prefix, 0, 21)
-	BytesCopy(data, 21, suffix, 0, 4)
-	cc := checkCode(prefix)
-	// verify
-	if BytesEqual(cc, suffix) {
-		network := EntityType(data[0])
-		return NewBTCAddress(base58, network)
-	}
-	//panic("address check code error")
-	return nil
-}
-
-// checkCode computes the 4-byte checksum for BTC address validation
-//
-// # Implements double SHA256 hashing (SHA256(SHA256(data))) and returns first 4 bytes
-//
-// Parameters:
-//   - data - Byte slice to compute checksum for (network+digest for BTC addresses)
-//
-// Returns: 4-byte checksum slice
-func checkCode(data []byte) []byte {
-	sha256d := SHA256(SHA256(data))
-	cc := make([]byte, 4)
-	BytesCopy(sha256d, 0, cc, 0, 4)
-	return cc
+	network := EntityType(version)
+	return NewBTCAddress(base58, network)
 }
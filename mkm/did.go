@@ -0,0 +1,241 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package mkm
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"github.com/dimchat/plugins-go/crypto/secp256k1"
+
+	. "github.com/dimchat/mkm-go/protocol"
+	. "github.com/dimchat/plugins-go/format"
+)
+
+/**
+ *  W3C Decentralized Identifiers
+ *  ~~~~~~~~~~~~~~~~~~~~~~~~~~~~~
+ *
+ *  data format:
+ *      "did:dim:{address}"
+ *      "did:dim:{name}:{address}/{terminal}"
+ *      "did:key:z{base58btc(multicodec + public key)}"
+ *
+ *  DID URL query ("?...") and fragment ("#...") are ignored
+ */
+
+//goland:noinspection GoSnakeCaseUsage
+const (
+	DID_PREFIX     = "did:"
+	DID_METHOD_DIM = "dim"
+	DID_METHOD_KEY = "key"
+)
+
+// multicodec of public keys in did:key (unsigned varint prefix)
+//
+//goland:noinspection GoSnakeCaseUsage
+const (
+	MULTICODEC_SECP256K1_PUB uint64 = 0xe7
+	MULTICODEC_ED25519_PUB   uint64 = 0xed
+)
+
+// IsDID checks whether the string is a DID (not a "name@address" ID)
+func IsDID(str string) bool {
+	return strings.HasPrefix(str, DID_PREFIX) && strings.IndexByte(str, '@') < 0
+}
+
+// FormatDID builds "did:dim:{name}:{address}/{terminal}" for the ID
+func FormatDID(did ID) string {
+	str := DID_PREFIX + DID_METHOD_DIM + ":"
+	name := did.Name()
+	if name != "" {
+		str += didEscape(name) + ":"
+	}
+	str += did.Address().String()
+	terminal := did.Terminal()
+	if terminal != "" {
+		str += "/" + didEscape(terminal)
+	}
+	return str
+}
+
+// splitDID returns the method, the method specific id and the DID URL path
+func splitDID(str string) (method, specific, path string) {
+	if !strings.HasPrefix(str, DID_PREFIX) {
+		return "", "", ""
+	}
+	str = str[len(DID_PREFIX):]
+	// ignore query & fragment
+	if pos := strings.IndexAny(str, "?#"); pos >= 0 {
+		str = str[:pos]
+	}
+	if pos := strings.IndexByte(str, '/'); pos >= 0 {
+		path = str[pos+1:]
+		str = str[:pos]
+	}
+	pos := strings.IndexByte(str, ':')
+	if pos <= 0 {
+		return "", "", ""
+	}
+	return str[:pos], str[pos+1:], path
+}
+
+// parseDID returns the name, address & terminal in the DID
+func parseDID(str string) (name string, address Address, terminal string) {
+	method, specific, path := splitDID(str)
+	if specific == "" {
+		return "", nil, ""
+	}
+	if path != "" {
+		terminal = didUnescape(path)
+		if terminal == "" {
+			return "", nil, ""
+		}
+	}
+	switch method {
+	case DID_METHOD_DIM:
+		pos := strings.LastIndexByte(specific, ':')
+		if pos > 0 {
+			name = didUnescape(specific[:pos])
+			if name == "" {
+				return "", nil, ""
+			}
+			specific = specific[pos+1:]
+		} else if pos == 0 {
+			return "", nil, ""
+		}
+		address = ParseAddress(specific)
+	case DID_METHOD_KEY:
+		codec, key := DecodeDIDKey(DID_PREFIX + method + ":" + specific)
+		address = GenerateDIDKeyAddress(codec, key)
+	}
+	return name, address, terminal
+}
+
+/**
+ *  did:key
+ */
+
+// EncodeDIDKey builds "did:key:z..." for the public key with multicodec
+func EncodeDIDKey(codec uint64, key []byte) string {
+	buf := make([]byte, binary.MaxVarintLen64+len(key))
+	n := binary.PutUvarint(buf, codec)
+	n += copy(buf[n:], key)
+	return DID_PREFIX + DID_METHOD_KEY + ":z" + EncodeBase58(buf[:n])
+}
+
+// DecodeDIDKey returns the multicodec & public key in "did:key:z..."
+//
+// Returns: nil key on error
+func DecodeDIDKey(str string) (uint64, []byte) {
+	method, specific, _ := splitDID(str)
+	if method != DID_METHOD_KEY || !strings.HasPrefix(specific, "z") {
+		// only base58btc supported
+		return 0, nil
+	}
+	data, err := DecodeBase58(specific[1:])
+	if err != nil {
+		return 0, nil
+	}
+	codec, n := binary.Uvarint(data)
+	if n <= 0 || n == len(data) {
+		return 0, nil
+	}
+	return codec, data[n:]
+}
+
+// GenerateDIDKeyAddress maps the did:key public key to a BTC address (USER),
+// secp256k1 keys get the same address as a BTC meta with the key
+//
+// Returns: nil for unsupported keys
+func GenerateDIDKeyAddress(codec uint64, key []byte) Address {
+	switch codec {
+	case MULTICODEC_SECP256K1_PUB:
+		if len(key) == 33 {
+			key = secp256k1.Decompress(key)
+			if key == nil {
+				return nil
+			}
+			// raw (X + Y) => "04" prefixed, same as the ECC key data
+			key = append([]byte{0x04}, key...)
+		} else if len(key) != 65 || key[0] != 0x04 {
+			return nil
+		}
+	case MULTICODEC_ED25519_PUB:
+		if len(key) != 32 {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return GenerateBTCAddress(key, USER)
+}
+
+/**
+ *  Percent Encoding
+ */
+
+// didEscape escapes all bytes except DID idchar (ALPHA / DIGIT / "." / "-" / "_")
+func didEscape(str string) string {
+	const hex = "0123456789ABCDEF"
+	buf := make([]byte, 0, len(str))
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		if isDIDChar(ch) {
+			buf = append(buf, ch)
+		} else {
+			buf = append(buf, '%', hex[ch>>4], hex[ch&0x0F])
+		}
+	}
+	return string(buf)
+}
+
+// didUnescape decodes "%XX" in name or terminal,
+// returns empty string on error, or when the result contains '@' or '/',
+// which would make an ID string that cannot be parsed back
+func didUnescape(str string) string {
+	if strings.IndexByte(str, '%') >= 0 {
+		data := PercentCoder{}.Decode(str)
+		if data == nil {
+			return ""
+		}
+		str = string(data)
+	}
+	if strings.ContainsAny(str, "@/") {
+		return ""
+	}
+	return str
+}
+
+func isDIDChar(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || '0' <= ch && ch <= '9' ||
+		ch == '.' || ch == '-' || ch == '_'
+}
@@ -0,0 +1,177 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package mkm_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/protocol"
+	. "github.com/dimchat/plugins-go/mkm"
+)
+
+func testAddress(t *testing.T) Address {
+	meta := GenerateMeta(BTC, GeneratePrivateKey(ECC), "")
+	address := GenerateAddress(meta, USER)
+	if address == nil {
+		t.Fatalf("failed to generate address")
+	}
+	return address
+}
+
+func TestIsDID(t *testing.T) {
+	address := testAddress(t).String()
+	for str, expected := range map[string]bool{
+		"did:dim:" + address:            true,
+		"did:dim:moky:" + address:       true,
+		"did:key:z6MkhaXgBZDvotDkL5257": true,
+		"moky@" + address:               false,
+		address:                         false,
+		"did:dim:moky@" + address:       false,
+	} {
+		if IsDID(str) != expected {
+			t.Errorf("IsDID(%s) != %v", str, expected)
+		}
+	}
+}
+
+func TestDIDRoundTrip(t *testing.T) {
+	address := testAddress(t)
+	for _, v := range []struct {
+		name, terminal, did string
+	}{
+		{"", "", "did:dim:" + address.String()},
+		{"moky", "", "did:dim:moky:" + address.String()},
+		{"moky", "home", "did:dim:moky:" + address.String() + "/home"},
+		{"", "DIM-1.0", "did:dim:" + address.String() + "/DIM-1.0"},
+		{"Albert Moky", "", "did:dim:Albert%20Moky:" + address.String()},
+		{"a:b", "c%d", "did:dim:a%3Ab:" + address.String() + "/c%25d"},
+		{"莫", "", "did:dim:%E8%8E%AB:" + address.String()},
+	} {
+		id := CreateID(v.name, address, v.terminal)
+		str := FormatDID(id)
+		if str != v.did {
+			t.Errorf("FormatDID(%s) = %s, expected %s", id, str, v.did)
+		}
+		back := ParseID(str)
+		if back == nil || back.String() != id.String() ||
+			back.Name() != v.name || back.Terminal() != v.terminal || !back.Address().Equal(address) {
+			t.Errorf("ParseID(%s) = %v, expected %s", str, back, id)
+		}
+	}
+	// query & fragment are ignored
+	id := ParseID("did:dim:moky:" + address.String() + "?service=files#key-1")
+	if id == nil || id.String() != "moky@"+address.String() {
+		t.Errorf("ParseID() = %v", id)
+	}
+	// bare DID as address
+	if a := ParseAddress("did:dim:" + address.String()); a == nil || !a.Equal(address) {
+		t.Errorf("ParseAddress() = %v", a)
+	}
+	if a := ParseAddress("did:dim:moky:" + address.String()); a != nil {
+		t.Errorf("ParseAddress() accepted name: %v", a)
+	}
+}
+
+func TestDIDInvalid(t *testing.T) {
+	address := testAddress(t).String()
+	for _, str := range []string{
+		"did:dim:a%40b:" + address,                        // '@' in name
+		"did:dim:a%2Fb:" + address,                        // '/' in name
+		"did:dim:" + address + "/a%40b",                   // '@' in terminal
+		"did:dim:" + address + "/a/b",                     // '/' in terminal
+		"did:dim:" + address + "/a%2fb",                   // '/' in terminal
+		"did:dim:a%zz:" + address,                         // bad escape
+		"did:dim::" + address,                             // empty name
+		"did:dim:moky:4DnqXWdTV8wuZgfqSCX9GjE2kNq7HJrUgX", // bad checksum
+		"did:dim:",
+		"did:" + address,
+		"did:web:example.com",
+	} {
+		if id := ParseID(str); id != nil {
+			t.Errorf("ParseID(%s) = %s", str, id)
+		}
+	}
+}
+
+func TestDIDKey(t *testing.T) {
+	// W3C did:key test vectors
+	for _, v := range []struct {
+		did   string
+		codec uint64
+		size  int
+	}{
+		{"did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK", MULTICODEC_ED25519_PUB, 32},
+		{"did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme", MULTICODEC_SECP256K1_PUB, 33},
+	} {
+		codec, key := DecodeDIDKey(v.did)
+		if codec != v.codec || len(key) != v.size {
+			t.Errorf("DecodeDIDKey(%s) = %x, %x", v.did, codec, key)
+			continue
+		}
+		if str := EncodeDIDKey(codec, key); str != v.did {
+			t.Errorf("EncodeDIDKey() = %s", str)
+		}
+		id := ParseID(v.did)
+		if id == nil || id.Address().Network() != USER {
+			t.Errorf("ParseID(%s) = %v", v.did, id)
+		}
+	}
+	// secp256k1 key gets the same address as the BTC meta
+	sKey := GeneratePrivateKey(ECC)
+	meta := GenerateMeta(BTC, sKey, "")
+	address := GenerateAddress(meta, USER)
+	pub := sKey.PublicKey().Data().Bytes()
+	if len(pub) != 65 || pub[0] != 0x04 {
+		t.Fatalf("unexpected public key: %x", pub)
+	}
+	compressed := append([]byte{0x02 | pub[64]&1}, pub[1:33]...)
+	for _, key := range [][]byte{pub, compressed} {
+		did := EncodeDIDKey(MULTICODEC_SECP256K1_PUB, key)
+		codec, data := DecodeDIDKey(did)
+		if codec != MULTICODEC_SECP256K1_PUB || !bytes.Equal(data, key) {
+			t.Errorf("did:key round trip failed: %s", did)
+		}
+		if id := ParseID(did); id == nil || !id.Address().Equal(address) {
+			t.Errorf("ParseID(%s) = %v, expected %s", did, id, address)
+		}
+	}
+	// unsupported
+	for _, str := range []string{
+		"did:key:6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK", // not base58btc
+		"did:key:z0OIl",                        // bad base58
+		EncodeDIDKey(0x1200, make([]byte, 33)), // P-256
+		EncodeDIDKey(MULTICODEC_ED25519_PUB, make([]byte, 31)),
+		EncodeDIDKey(MULTICODEC_SECP256K1_PUB, append([]byte{0x05}, make([]byte, 32)...)),
+		EncodeDIDKey(MULTICODEC_SECP256K1_PUB, make([]byte, 65)),
+	} {
+		if id := ParseID(str); id != nil {
+			t.Errorf("ParseID(%s) = %s", str, id)
+		}
+	}
+}
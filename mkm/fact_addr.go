@@ -93,7 +93,13 @@ func (factory BaseAddressFactory) parse(str string) Address {
 		}
 	}
 	var result Address
-	if 26 <= size && size <= 35 {
+	if IsDID(str) {
+		// "did:dim:{address}", "did:key:z..."
+		name, address, terminal := parseDID(str)
+		if name == "" && terminal == "" {
+			result = address
+		}
+	} else if 26 <= size && size <= 35 {
 		// BTC
 		result = ParseBTCAddress(str)
 	} else if size == 42 {
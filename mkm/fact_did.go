@@ -86,6 +86,17 @@ func (factory IdentifierFactory) parse(identifier string) ID {
 	var name string
 	var address Address
 	var terminal string
+	// W3C DID: "did:dim:{name}:{address}/{terminal}", "did:key:z..."
+	if IsDID(identifier) {
+		name, address, terminal = parseDID(identifier)
+		if address == nil {
+			//panic("DID error: " + identifier)
+			return nil
+		}
+		// normalize to "name@address/terminal"
+		str := IDConcat(name, address, terminal)
+		return factory.newID(str, name, address, terminal)
+	}
 	// split ID string
 	str := identifier
 	pos := strings.IndexByte(str, '/')
@@ -0,0 +1,92 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package mkm
+
+import (
+	. "github.com/dimchat/core-go/format"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/ext"
+	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/mkm-go/protocol"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/format"
+)
+
+/**
+ *  Canonical Document Signing
+ *  ~~~~~~~~~~~~~~~~~~~~~~~~~~
+ *
+ *  Document.Sign() encodes the properties with the global JSON coder,
+ *  this one always uses RFC 8785 (JCS), no matter which coder is set,
+ *  so the same properties get the same "data" on every client.
+ *
+ *  Meta needs no option: its fingerprint is signed over the seed string.
+ */
+
+// SignDocumentCanonical updates the sign time, encodes the document properties
+// as canonical JSON and signs them with the private key.
+//
+// Returns a new signed document with the same fields,
+// nil if the properties cannot be encoded exactly (NaN, big integers, ...)
+func SignDocumentCanonical(doc Document, sKey SignKey) Document {
+	// 1. update sign time
+	doc.SetProperty("time", TimeToFloat64(TimeNow()))
+	// 2. encode & sign
+	info := doc.Properties()
+	if info == nil {
+		return nil
+	}
+	data, err := CanonicalJSONEncode(info)
+	if err != nil {
+		//panic(err)
+		return nil
+	}
+	signature := sKey.Sign(UTF8Encode(data))
+	if signature == nil {
+		//panic("failed to sign document data")
+		return nil
+	}
+	ted := NewBase64DataWithBytes(signature)
+	// 3. create signed document with 'data' & 'signature'
+	helper := GetGeneralAccountHelper()
+	docType := helper.GetDocumentType(doc.Map(), "")
+	signed := CreateDocument(docType, data, ted)
+	if signed == nil {
+		return nil
+	}
+	// 4. copy other fields ('did', ...)
+	for key, value := range doc.Map() {
+		if key != "type" && key != "data" && key != "signature" {
+			signed.Set(key, value)
+		}
+	}
+	return signed
+}
@@ -0,0 +1,85 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package mkm_test
+
+import (
+	"testing"
+
+	"github.com/dimchat/plugins-go/ext"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/protocol"
+	. "github.com/dimchat/plugins-go/format"
+	. "github.com/dimchat/plugins-go/mkm"
+)
+
+func init() {
+	ext.ExtensionLoader{}.Load()
+	ext.PluginLoader{}.Load()
+}
+
+func TestSignDocumentCanonical(t *testing.T) {
+	sKey := GeneratePrivateKey(ECC)
+	doc := CreateDocument(VISA, "", nil)
+	doc.Set("did", "moky@4DnqXWdTV8wuZgfqSCX9GjE2kNq7HJrUgQ")
+	doc.SetProperty("name", "Moky")
+	doc.SetProperty("avatar", "https://example.com/a.png")
+	doc.SetProperty("sn", 9007199254740992)
+
+	signed := SignDocumentCanonical(doc, sKey)
+	if signed == nil {
+		t.Fatalf("failed to sign document")
+	}
+	// same fields
+	if signed.GetString("did", "") != doc.GetString("did", "") ||
+		signed.GetString("type", "") != VISA {
+		t.Errorf("document fields lost: %v", signed.Map())
+	}
+	// data is canonical JSON of the properties
+	data := signed.GetString("data", "")
+	expected, err := CanonicalJSONEncode(doc.Properties())
+	if err != nil || data != expected {
+		t.Errorf("data = %s, expected %s (%v)", data, expected, err)
+	}
+	if !signed.IsValid() || signed.GetProperty("name") != "Moky" {
+		t.Errorf("signed document not valid: %v", signed.Map())
+	}
+	// verify again after transport
+	received := ParseDocument(signed.CopyMap(false))
+	if received == nil || !received.Verify(sKey.PublicKey()) {
+		t.Errorf("signature not verified")
+	}
+}
+
+func TestSignDocumentCanonicalBigInteger(t *testing.T) {
+	sKey := GeneratePrivateKey(ECC)
+	doc := CreateDocument(BULLETIN, "", nil)
+	doc.SetProperty("sn", int64(9007199254740993))
+	if signed := SignDocumentCanonical(doc, sKey); signed != nil {
+		t.Errorf("big integer signed: %s", signed.GetString("data", ""))
+	}
+}
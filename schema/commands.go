@@ -0,0 +1,97 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package schema
+
+/**
+ *  Command
+ *  ~~~~~~~
+ *
+ *  data format: {
+ *      "type"    : i2s(0x88),
+ *      "sn"      : 123,
+ *      "command" : "...",
+ *  }
+ */
+var CommandSchema = ContentSchema.Extend("command",
+	Field{Name: "command", Kind: KindString, Required: true},
+)
+
+var MetaCommandSchema = CommandSchema.Extend("meta",
+	Field{Name: "did", Kind: KindID, Required: true},
+	Field{Name: "meta", Kind: KindMap, Ref: META_SCHEMA},
+)
+
+var DocumentCommandSchema = MetaCommandSchema.Extend("documents",
+	Field{Name: "documents", Kind: KindArray, Items: &Field{Kind: KindMap, Required: true, Ref: DOCUMENT_SCHEMA}},
+	Field{Name: "last_time", Kind: KindNumber},
+)
+
+var ReceiptCommandSchema = CommandSchema.Extend("receipt",
+	Field{Name: "text", Kind: KindString},
+	Field{Name: "origin", Kind: KindMap, Schema: originSchema},
+)
+
+// HistoryCommandSchema: "type" is 0x89
+var HistoryCommandSchema = CommandSchema.Extend("history",
+	Field{Name: "event", Kind: KindString},
+)
+
+/**
+ *  Group Commands
+ *  ~~~~~~~~~~~~~~
+ *
+ *  data format: {
+ *      "type"    : i2s(0x89),
+ *      "sn"      : 123,
+ *      "command" : "reset",   // "invite", "quit", ...
+ *      "group"   : "{GID}",
+ *      "members" : ["{ID}"],  // or "member": "{ID}"
+ *  }
+ */
+var GroupCommandSchema = HistoryCommandSchema.Extend("group",
+	Field{Name: "group", Kind: KindID, Required: true},
+	Field{Name: "member", Kind: KindID},
+	Field{Name: "members", Kind: KindArray, Items: &Field{Kind: KindID, Required: true}},
+)
+
+var InviteCommandSchema = GroupCommandSchema.Extend("invite").RequireAnyOf("member", "members")
+
+var ExpelCommandSchema = GroupCommandSchema.Extend("expel").RequireAnyOf("member", "members")
+
+var JoinCommandSchema = GroupCommandSchema.Extend("join",
+	Field{Name: "text", Kind: KindString},
+)
+
+var QuitCommandSchema = GroupCommandSchema.Extend("quit",
+	Field{Name: "text", Kind: KindString},
+)
+
+var ResetCommandSchema = GroupCommandSchema.Extend("reset").RequireAnyOf("member", "members")
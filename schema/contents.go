@@ -0,0 +1,128 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package schema
+
+/**
+ *  Content
+ *  ~~~~~~~
+ *
+ *  data format: {
+ *      "type"  : i2s(0x00),
+ *      "sn"    : 123,
+ *      "time"  : 123,       // optional
+ *      "group" : "{GID}",   // optional
+ *  }
+ */
+var ContentSchema = NewSchema("content",
+	Field{Name: "type", Kind: KindString | KindInteger, Required: true},
+	Field{Name: "sn", Kind: KindInteger, Required: true},
+	Field{Name: "time", Kind: KindNumber},
+	Field{Name: "group", Kind: KindID},
+)
+
+var TextContentSchema = ContentSchema.Extend("text",
+	Field{Name: "text", Kind: KindString, Required: true},
+)
+
+// FileContentSchema: "data", "filename", "URL" and "key" (decrypt key)
+var FileContentSchema = ContentSchema.Extend("file",
+	Field{Name: "data", Kind: KindData},
+	Field{Name: "filename", Kind: KindString},
+	Field{Name: "URL", Kind: KindString},
+	Field{Name: "key", Kind: KindMap},
+)
+
+var ImageContentSchema = FileContentSchema.Extend("image",
+	Field{Name: "thumbnail", Kind: KindFile},
+)
+
+var AudioContentSchema = FileContentSchema.Extend("audio",
+	Field{Name: "duration", Kind: KindNumber},
+	Field{Name: "text", Kind: KindString},
+)
+
+var VideoContentSchema = FileContentSchema.Extend("video",
+	Field{Name: "snapshot", Kind: KindFile},
+)
+
+var PageContentSchema = ContentSchema.Extend("page",
+	Field{Name: "title", Kind: KindString},
+	Field{Name: "icon", Kind: KindFile},
+	Field{Name: "desc", Kind: KindString},
+	Field{Name: "URL", Kind: KindString},
+	Field{Name: "HTML", Kind: KindString},
+).RequireAnyOf("URL", "HTML")
+
+var NameCardSchema = ContentSchema.Extend("card",
+	Field{Name: "did", Kind: KindID, Required: true},
+	Field{Name: "name", Kind: KindString},
+	Field{Name: "avatar", Kind: KindFile},
+)
+
+// envelope of the quoted message, with its serial number
+var originSchema = NewSchema("origin",
+	Field{Name: "sender", Kind: KindID, Required: true},
+	Field{Name: "receiver", Kind: KindID},
+	Field{Name: "type", Kind: KindString | KindInteger},
+	Field{Name: "group", Kind: KindID},
+	Field{Name: "sn", Kind: KindInteger},
+	Field{Name: "signature", Kind: KindString},
+)
+
+var QuoteContentSchema = ContentSchema.Extend("quote",
+	Field{Name: "text", Kind: KindString, Required: true},
+	Field{Name: "origin", Kind: KindMap, Required: true, Schema: originSchema},
+)
+
+var MoneyContentSchema = ContentSchema.Extend("money",
+	Field{Name: "currency", Kind: KindString, Required: true},
+	Field{Name: "amount", Kind: KindNumber, Required: true},
+)
+
+var TransferContentSchema = MoneyContentSchema.Extend("transfer",
+	Field{Name: "remitter", Kind: KindID},
+	Field{Name: "remittee", Kind: KindID},
+)
+
+var ArrayContentSchema = ContentSchema.Extend("array",
+	Field{Name: "contents", Kind: KindArray, Required: true, Items: &Field{Kind: KindContent, Required: true}},
+)
+
+var CombineContentSchema = ContentSchema.Extend("combine",
+	Field{Name: "title", Kind: KindString},
+	Field{Name: "messages", Kind: KindArray, Required: true, Items: &Field{Kind: KindMap, Required: true, Ref: INSTANT_SCHEMA}},
+)
+
+// ForwardContentSchema: top-secret message(s) in "forward" or "secrets"
+var ForwardContentSchema = ContentSchema.Extend("forward",
+	Field{Name: "forward", Kind: KindMap, Ref: RELIABLE_SCHEMA},
+	Field{Name: "secrets", Kind: KindArray, Items: &Field{Kind: KindMap, Required: true, Ref: RELIABLE_SCHEMA}},
+).RequireAnyOf("forward", "secrets")
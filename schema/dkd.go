@@ -0,0 +1,71 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package schema
+
+/**
+ *  Envelope
+ *  ~~~~~~~~
+ *
+ *  data format: {
+ *      "sender"   : "moki@xxx",
+ *      "receiver" : "hulk@yyy",
+ *      "time"     : 123,
+ *
+ *      "type"     : 0x01,      // optional
+ *      "group"    : "{GID}",   // optional
+ *  }
+ */
+var EnvelopeSchema = NewSchema(ENVELOPE_SCHEMA,
+	Field{Name: "sender", Kind: KindID, Required: true},
+	Field{Name: "receiver", Kind: KindID},
+	Field{Name: "time", Kind: KindNumber},
+	Field{Name: "type", Kind: KindString | KindInteger},
+	Field{Name: "group", Kind: KindID},
+)
+
+// InstantMessageSchema = envelope + content
+var InstantMessageSchema = EnvelopeSchema.Extend(INSTANT_SCHEMA,
+	Field{Name: "content", Kind: KindContent, Required: true},
+)
+
+// SecureMessageSchema = envelope + encrypted data + encrypted key(s)
+var SecureMessageSchema = EnvelopeSchema.Extend(SECURE_SCHEMA,
+	Field{Name: "data", Kind: KindData, Required: true},
+	Field{Name: "key", Kind: KindData},
+	Field{Name: "keys", Kind: KindMap, Items: &Field{Kind: KindData}},
+)
+
+// ReliableMessageSchema = secure message + signature (+ meta/visa attached)
+var ReliableMessageSchema = SecureMessageSchema.Extend(RELIABLE_SCHEMA,
+	Field{Name: "signature", Kind: KindData, Required: true},
+	Field{Name: "meta", Kind: KindMap, Ref: META_SCHEMA},
+	Field{Name: "visa", Kind: KindMap, Ref: DOCUMENT_SCHEMA},
+)
@@ -0,0 +1,67 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package schema
+
+/**
+ *  Meta
+ *  ~~~~
+ *
+ *  data format: {
+ *      "type"        : "1",
+ *      "key"         : "{public key}",
+ *      "seed"        : "moKy",          // optional
+ *      "fingerprint" : "{base64}",      // required when seed exists
+ *  }
+ */
+var MetaSchema = NewSchema(META_SCHEMA,
+	Field{Name: "type", Kind: KindString | KindInteger, Required: true},
+	Field{Name: "key", Kind: KindPublicKey, Required: true},
+	Field{Name: "seed", Kind: KindString},
+	Field{Name: "fingerprint", Kind: KindData},
+).RequireTogether("seed", "fingerprint")
+
+/**
+ *  Document
+ *  ~~~~~~~~
+ *
+ *  data format: {
+ *      "did"       : "{ID}",
+ *      "type"      : "visa",       // optional
+ *      "data"      : "{JSON}",     // properties
+ *      "signature" : "{base64}",
+ *  }
+ */
+var DocumentSchema = NewSchema(DOCUMENT_SCHEMA,
+	Field{Name: "did", Kind: KindID},
+	Field{Name: "type", Kind: KindString},
+	Field{Name: "data", Kind: KindString, Required: true},
+	Field{Name: "signature", Kind: KindData, Required: true},
+)
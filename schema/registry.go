@@ -0,0 +1,152 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package schema
+
+import (
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/types"
+)
+
+//goland:noinspection GoSnakeCaseUsage
+const (
+	ENVELOPE_SCHEMA = "envelope"
+	INSTANT_SCHEMA  = "instant"
+	SECURE_SCHEMA   = "secure"
+	RELIABLE_SCHEMA = "reliable"
+	META_SCHEMA     = "meta"
+	DOCUMENT_SCHEMA = "document"
+)
+
+// ContentSchemaName returns the registry name for a content type
+func ContentSchemaName(msgType string) string {
+	return "content:" + msgType
+}
+
+// CommandSchemaName returns the registry name for a command
+func CommandSchemaName(cmd string) string {
+	return "command:" + cmd
+}
+
+//
+//  Schema Registry
+//
+
+var sharedSchemas = make(map[string]*Schema, 64)
+
+func SetSchema(name string, schema *Schema) {
+	sharedSchemas[name] = schema
+}
+
+func GetSchema(name string) *Schema {
+	return sharedSchemas[name]
+}
+
+// getContentSchema finds the schema for a content map,
+// in the same way the factories find the content parser:
+//
+//	command  - by 'command', then 'group' for group commands
+//	content  - by 'type', then '*' for unknown types
+func getContentSchema(info StringKeyMap) *Schema {
+	msgType := ConvertString(info["type"], "")
+	if msgType == ContentType.COMMAND || msgType == ContentType.HISTORY {
+		cmd := ConvertString(info["command"], "")
+		schema := GetSchema(CommandSchemaName(cmd))
+		if schema == nil && info["group"] != nil {
+			schema = GetSchema(CommandSchemaName("group"))
+		}
+		if schema != nil {
+			return schema
+		}
+	}
+	schema := GetSchema(ContentSchemaName(msgType))
+	if schema == nil {
+		schema = GetSchema(ContentSchemaName("*"))
+	}
+	return schema
+}
+
+// Validate checks the object with the schema registered for name
+//
+// Returns: *ValidationError with all violations, nil when valid or no schema
+func Validate(name string, info StringKeyMap) error {
+	schema := GetSchema(name)
+	if schema == nil {
+		return nil
+	}
+	return newError(schema, schema.Validate(info))
+}
+
+// ValidateContent checks a content (or command) with the schema for its type
+func ValidateContent(info StringKeyMap) error {
+	schema := getContentSchema(info)
+	if schema == nil {
+		return nil
+	}
+	return newError(schema, schema.Validate(info))
+}
+
+func newError(schema *Schema, violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{
+		Schema:     schema.Name,
+		Violations: violations,
+	}
+}
+
+//
+//  Strict Mode
+//
+
+var strictMode = false
+
+// SetStrictMode turns on/off schema checking in the factories,
+// when on, Parse*() returns nil for any object with violations
+func SetStrictMode(strict bool) {
+	strictMode = strict
+}
+
+func IsStrictMode() bool {
+	return strictMode
+}
+
+// CheckSchema returns false only when strict mode is on
+// and the object violates the schema registered for name
+func CheckSchema(name string, info StringKeyMap) bool {
+	return !strictMode || Validate(name, info) == nil
+}
+
+// CheckContent returns false only when strict mode is on
+// and the content violates the schema for its type
+func CheckContent(info StringKeyMap) bool {
+	return !strictMode || ValidateContent(info) == nil
+}
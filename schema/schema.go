@@ -0,0 +1,461 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/mkm-go/protocol"
+	. "github.com/dimchat/mkm-go/types"
+)
+
+// Kind is a bit set of value types accepted by a field,
+// a value matches when it matches any one of the bits
+type Kind uint
+
+const KindAny Kind = 0
+
+const (
+	KindString    Kind = 1 << iota
+	KindNumber         // int, uint, float or json.Number
+	KindInteger        // number without fraction
+	KindBool           //
+	KindMap            // JSON object
+	KindArray          // JSON array
+	KindID             // string parsed by ParseID
+	KindData           // transportable data (base64 string, data URI, ...)
+	KindFile           // transportable file (URL, data URI or map)
+	KindContent        // content map, validated by its 'type' (and 'command')
+	KindPublicKey      // key map parsed by ParsePublicKey (JWK accepted)
+)
+
+var kindNames = []struct {
+	kind Kind
+	name string
+}{
+	{KindString, "string"},
+	{KindNumber, "number"},
+	{KindInteger, "integer"},
+	{KindBool, "boolean"},
+	{KindMap, "object"},
+	{KindArray, "array"},
+	{KindID, "ID"},
+	{KindData, "data"},
+	{KindFile, "file"},
+	{KindContent, "content"},
+	{KindPublicKey, "public key"},
+}
+
+func (kind Kind) String() string {
+	if kind == KindAny {
+		return "any"
+	}
+	var names []string
+	for _, item := range kindNames {
+		if kind&item.kind != 0 {
+			names = append(names, item.name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// Field describes one entry of a JSON object
+type Field struct {
+	Name     string
+	Kind     Kind
+	Required bool
+
+	// Items describes the elements of an array,
+	// or the values of an object (the keys are not checked)
+	Items *Field
+
+	// Schema validates a nested object;
+	// Ref names a registered schema instead, resolved at validation time
+	Schema *Schema
+	Ref    string
+}
+
+// Schema is a declarative description of a JSON object
+//
+// Keys not listed in the fields are allowed,
+// so the protocol can be extended without breaking old validators
+type Schema struct {
+	Name   string
+	Fields []Field
+
+	// AnyOf lists key groups which need at least one key present
+	AnyOf [][]string
+	// Together lists key groups which must be all present or all absent
+	Together [][]string
+}
+
+func NewSchema(name string, fields ...Field) *Schema {
+	return &Schema{
+		Name:   name,
+		Fields: fields,
+	}
+}
+
+// Extend creates a new schema with all rules of this one,
+// fields with the same name are replaced
+func (s *Schema) Extend(name string, fields ...Field) *Schema {
+	all := make([]Field, 0, len(s.Fields)+len(fields))
+	for _, base := range s.Fields {
+		if indexOfField(fields, base.Name) < 0 {
+			all = append(all, base)
+		}
+	}
+	all = append(all, fields...)
+	return &Schema{
+		Name:     name,
+		Fields:   all,
+		AnyOf:    append([][]string{}, s.AnyOf...),
+		Together: append([][]string{}, s.Together...),
+	}
+}
+
+// RequireAnyOf adds a rule that at least one of the keys must be present
+func (s *Schema) RequireAnyOf(keys ...string) *Schema {
+	s.AnyOf = append(s.AnyOf, keys)
+	return s
+}
+
+// RequireTogether adds a rule that the keys must be all present or all absent
+func (s *Schema) RequireTogether(keys ...string) *Schema {
+	s.Together = append(s.Together, keys)
+	return s
+}
+
+func indexOfField(fields []Field, name string) int {
+	for i, item := range fields {
+		if item.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+/**
+ *  Violations
+ */
+
+// Violation is a single schema error, located by a JSON path like "$.content.sn"
+type Violation struct {
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// ValidationError wraps all violations found in one object
+type ValidationError struct {
+	Schema     string
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		lines[i] = v.String()
+	}
+	return fmt.Sprintf("schema %s: %s", e.Schema, strings.Join(lines, "; "))
+}
+
+/**
+ *  Validating
+ */
+
+// nesting limit for contents inside contents
+const maxSchemaDepth = 32
+
+// Validate checks the object against this schema,
+// returns every violation found (nil when valid)
+func (s *Schema) Validate(info StringKeyMap) []Violation {
+	v := &validator{}
+	v.checkObject(s, info, "$")
+	return v.violations
+}
+
+type validator struct {
+	violations []Violation
+	depth      int
+}
+
+func (v *validator) report(path, format string, args ...any) {
+	v.violations = append(v.violations, Violation{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) checkObject(s *Schema, info StringKeyMap, path string) {
+	if v.depth >= maxSchemaDepth {
+		v.report(path, "nested too deep")
+		return
+	}
+	v.depth++
+	defer func() { v.depth-- }()
+	for _, field := range s.Fields {
+		value := info[field.Name]
+		if value == nil {
+			if field.Required {
+				v.report(childPath(path, field.Name), "required field missing")
+			}
+			continue
+		}
+		v.checkValue(&field, value, childPath(path, field.Name))
+	}
+	for _, keys := range s.AnyOf {
+		if countKeys(info, keys) == 0 {
+			v.report(path, "one of %v required", keys)
+		}
+	}
+	for _, keys := range s.Together {
+		count := countKeys(info, keys)
+		if count > 0 && count < len(keys) {
+			v.report(path, "fields %v must appear together", keys)
+		}
+	}
+}
+
+func (v *validator) checkValue(field *Field, value any, path string) {
+	kind := field.Kind
+	if kind != KindAny && !matchKind(kind, value) {
+		v.report(path, "expected %s, got %s", kind, typeName(value))
+		return
+	}
+	// nested object
+	if field.Schema != nil || field.Ref != "" || kind == KindContent {
+		dict := FetchMap(value)
+		if dict == nil {
+			// string form of a file or an ID, nothing inside
+		} else if kind == KindContent {
+			v.checkContent(dict, path)
+		} else if field.Schema != nil {
+			v.checkObject(field.Schema, dict, path)
+		} else if ref := GetSchema(field.Ref); ref != nil {
+			v.checkObject(ref, dict, path)
+		}
+	}
+	// elements
+	if field.Items == nil {
+		return
+	}
+	if list := asList(value); list != nil {
+		for i, item := range list {
+			v.checkItem(field.Items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	} else if dict := FetchMap(value); dict != nil {
+		for key, item := range dict {
+			v.checkItem(field.Items, item, childPath(path, key))
+		}
+	}
+}
+
+func (v *validator) checkItem(field *Field, item any, path string) {
+	if item == nil {
+		if field.Required {
+			v.report(path, "null value")
+		}
+		return
+	}
+	v.checkValue(field, item, path)
+}
+
+func (v *validator) checkContent(info StringKeyMap, path string) {
+	s := getContentSchema(info)
+	if s != nil {
+		v.checkObject(s, info, path)
+	}
+}
+
+func countKeys(info StringKeyMap, keys []string) int {
+	count := 0
+	for _, name := range keys {
+		if info[name] != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func childPath(path, key string) string {
+	if isIdentifier(key) {
+		return path + "." + key
+	}
+	text := strings.ReplaceAll(key, `\`, `\\`)
+	text = strings.ReplaceAll(text, `'`, `\'`)
+	return path + "['" + text + "']"
+}
+
+func isIdentifier(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, ch := range key {
+		if ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' {
+			continue
+		} else if i > 0 && ch >= '0' && ch <= '9' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+/**
+ *  Value Types
+ */
+
+func matchKind(kind Kind, value any) bool {
+	if kind&KindString != 0 && isString(value) {
+		return true
+	}
+	if kind&KindNumber != 0 && isNumber(value) {
+		return true
+	}
+	if kind&KindInteger != 0 && isInteger(value) {
+		return true
+	}
+	if kind&KindBool != 0 {
+		if _, ok := value.(bool); ok {
+			return true
+		}
+	}
+	if kind&(KindMap|KindContent) != 0 && !isString(value) && FetchMap(value) != nil {
+		return true
+	}
+	if kind&KindArray != 0 && asList(value) != nil {
+		return true
+	}
+	if kind&KindID != 0 {
+		if _, ok := value.(ID); ok {
+			return true
+		} else if isString(value) && ParseID(value) != nil {
+			return true
+		}
+	}
+	if kind&KindData != 0 && ParseTransportableData(value) != nil {
+		return true
+	}
+	if kind&KindFile != 0 && ParseTransportableFile(value) != nil {
+		return true
+	}
+	if kind&KindPublicKey != 0 {
+		if _, ok := value.(PublicKey); ok {
+			return true
+		} else if !isString(value) && ParsePublicKey(value) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isString(value any) bool {
+	_, ok := value.(string)
+	return ok
+}
+
+func isNumber(value any) bool {
+	switch v := value.(type) {
+	case json.Number:
+		_, err := v.Float64()
+		return err == nil
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isInteger(value any) bool {
+	switch v := value.(type) {
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return true
+		}
+		f, err := v.Float64()
+		return err == nil && f == math.Trunc(f)
+	case float32:
+		return float64(v) == math.Trunc(float64(v))
+	case float64:
+		return v == math.Trunc(v) && !math.IsInf(v, 0)
+	}
+	return isNumber(value)
+}
+
+// asList returns the elements of an array value,
+// strings and byte slices are not arrays here
+func asList(value any) []any {
+	switch value.(type) {
+	case string, []byte:
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil
+	}
+	return FetchList(value)
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	}
+	if isNumber(value) {
+		if isInteger(value) {
+			return "integer"
+		}
+		return "number"
+	} else if asList(value) != nil {
+		return "array"
+	} else if FetchMap(value) != nil {
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
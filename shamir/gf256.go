@@ -0,0 +1,67 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package shamir
+
+//
+//  Arithmetic in GF(2^8) with the AES polynomial x^8 + x^4 + x^3 + x + 1
+//
+//  Addition (and subtraction) is XOR; multiplication avoids lookup tables
+//  and data-dependent branches, so the running time does not depend on the
+//  secret bytes
+//
+
+func gfAdd(a, b byte) byte {
+	return a ^ b
+}
+
+func gfMul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		// p ^= a if the lowest bit of b is set
+		p ^= a & -(b & 1)
+		// a *= x (mod 0x11b)
+		carry := -(a >> 7)
+		a = (a << 1) ^ (0x1b & carry)
+		b >>= 1
+	}
+	return p
+}
+
+// gfInv returns a^-1 = a^254, the inverse of zero is zero
+func gfInv(a byte) byte {
+	// a^2, a^4, ... a^128
+	result := byte(1)
+	square := a
+	for i := 1; i < 8; i++ {
+		square = gfMul(square, square)
+		result = gfMul(result, square)
+	}
+	return result
+}
+
+func gfDiv(a, b byte) byte {
+	return gfMul(a, gfInv(b))
+}
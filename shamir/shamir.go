@@ -0,0 +1,113 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package shamir
+
+import (
+	"crypto/rand"
+
+	. "github.com/dimchat/plugins-go/types"
+)
+
+// Split splits the secret into shares with Shamir's Secret Sharing,
+// any 'threshold' of the 'total' shares can recover the secret,
+// while fewer shares reveal nothing about it
+//
+// Each byte of the secret is the constant term of a random polynomial with
+// degree (threshold - 1), share 'x' holds the values of all polynomials at x
+//
+// Parameters:
+//   - secret    - secret data
+//   - threshold - minimum number of shares to recover the secret (2 ~ total)
+//   - total     - number of shares (threshold ~ 255)
+//
+// Returns: shares indexed from 1 to total, nil on invalid parameters
+func Split(secret []byte, threshold, total int) map[byte][]byte {
+	if len(secret) == 0 || threshold < 2 || total < threshold || total > 255 {
+		//panic("secret sharing parameters error")
+		return nil
+	}
+	shares := make(map[byte][]byte, total)
+	for x := 1; x <= total; x++ {
+		shares[byte(x)] = make([]byte, len(secret))
+	}
+	coefficients := make([]byte, threshold)
+	for i, value := range secret {
+		coefficients[0] = value
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			panic(err)
+		}
+		for x, share := range shares {
+			share[i] = evaluate(coefficients, x)
+		}
+	}
+	BytesWipe(coefficients)
+	return shares
+}
+
+// Combine recovers the secret from the shares with Lagrange interpolation at x = 0
+//
+// All given shares are used, so the caller should pass at least 'threshold'
+// shares; with fewer shares the result is garbage, not an error
+//
+// Returns: nil if shares are empty or not the same length
+func Combine(shares map[byte][]byte) []byte {
+	var size int
+	for x, share := range shares {
+		if x == 0 || len(share) == 0 {
+			return nil
+		} else if size == 0 {
+			size = len(share)
+		} else if len(share) != size {
+			return nil
+		}
+	}
+	if size == 0 {
+		return nil
+	}
+	secret := make([]byte, size)
+	for xi, share := range shares {
+		// basis polynomial l_i(0) = prod(x_j / (x_j - x_i)), j != i
+		basis := byte(1)
+		for xj := range shares {
+			if xj != xi {
+				basis = gfMul(basis, gfDiv(xj, gfAdd(xj, xi)))
+			}
+		}
+		for i, y := range share {
+			secret[i] = gfAdd(secret[i], gfMul(y, basis))
+		}
+	}
+	return secret
+}
+
+// evaluate calculates the polynomial at x with Horner's method
+func evaluate(coefficients []byte, x byte) byte {
+	var y byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = gfAdd(gfMul(y, x), coefficients[i])
+	}
+	return y
+}
@@ -0,0 +1,127 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package shamir_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/format"
+	"github.com/dimchat/plugins-go/ext"
+	. "github.com/dimchat/plugins-go/shamir"
+)
+
+func init() {
+	ext.ExtensionLoader{}.Load()
+	ext.PluginLoader{}.Load()
+}
+
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("the quick brown fox jumps over the lazy dog")
+	shares := Split(secret, 3, 5)
+	if len(shares) != 5 {
+		t.Fatalf("shares count = %d", len(shares))
+	}
+	// every 3 of the 5 shares recover the secret
+	for a := byte(1); a <= 5; a++ {
+		for b := a + 1; b <= 5; b++ {
+			for c := b + 1; c <= 5; c++ {
+				points := map[byte][]byte{a: shares[a], b: shares[b], c: shares[c]}
+				if out := Combine(points); !bytes.Equal(out, secret) {
+					t.Errorf("shares (%d, %d, %d) = %q", a, b, c, out)
+				}
+			}
+		}
+	}
+	// all shares work too
+	if out := Combine(shares); !bytes.Equal(out, secret) {
+		t.Errorf("all shares = %q", out)
+	}
+	// 2 shares are not enough
+	if out := Combine(map[byte][]byte{1: shares[1], 4: shares[4]}); bytes.Equal(out, secret) {
+		t.Errorf("secret recovered from 2 shares")
+	}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	secret := []byte("secret")
+	params := [][2]int{{1, 5}, {6, 5}, {2, 256}, {0, 0}}
+	for _, p := range params {
+		if Split(secret, p[0], p[1]) != nil {
+			t.Errorf("split with threshold = %d, total = %d", p[0], p[1])
+		}
+	}
+	if Split(nil, 2, 3) != nil {
+		t.Errorf("split empty secret")
+	}
+	if Combine(nil) != nil || Combine(map[byte][]byte{0: secret}) != nil {
+		t.Errorf("combined invalid shares")
+	}
+	if Combine(map[byte][]byte{1: secret, 2: secret[1:]}) != nil {
+		t.Errorf("combined shares with different lengths")
+	}
+}
+
+func TestPrivateKeyShares(t *testing.T) {
+	key := GeneratePrivateKey(ECC)
+	shares := SplitPrivateKey(key, 3, 5)
+	if len(shares) != 5 {
+		t.Fatalf("shares count = %d", len(shares))
+	}
+	// transfer the shares as JSON
+	parsed := make([]*KeyShare, len(shares))
+	for index, share := range shares {
+		info := JSONDecodeMap(JSONEncodeMap(share.Map()))
+		parsed[index] = ParseKeyShare(info)
+		if parsed[index] == nil {
+			t.Fatalf("failed to parse share %d", index+1)
+		}
+	}
+	// threshold shares
+	other := CombinePrivateKey([]*KeyShare{parsed[4], parsed[0], parsed[2]})
+	if other == nil || !other.Equal(key) {
+		t.Fatalf("private key not recovered")
+	}
+	// too few shares
+	if CombinePrivateKey(parsed[:2]) != nil {
+		t.Errorf("private key recovered from 2 shares")
+	}
+	// duplicated shares do not count
+	if CombinePrivateKey([]*KeyShare{parsed[1], parsed[1], parsed[3]}) != nil {
+		t.Errorf("private key recovered from duplicated shares")
+	}
+	// shares from another split
+	others := SplitPrivateKey(GeneratePrivateKey(ECC), 3, 5)
+	if CombinePrivateKey([]*KeyShare{parsed[0], parsed[1], others[2]}) != nil {
+		t.Errorf("private key recovered from mixed shares")
+	}
+	// invalid threshold
+	if SplitPrivateKey(key, 1, 5) != nil {
+		t.Errorf("split with threshold = 1")
+	}
+}
@@ -0,0 +1,205 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package shamir
+
+import (
+	. "github.com/dimchat/core-go/format"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/digest"
+	. "github.com/dimchat/mkm-go/format"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/types"
+)
+
+// GF256 is the secret sharing scheme of the key shares
+const GF256 = "SSS/GF256"
+
+func NewKeyShare(index byte, threshold, total int, data []byte, checksum string) *KeyShare {
+	ted := NewBase64DataWithBytes(data)
+	info := NewMap()
+	info["scheme"] = GF256
+	info["index"] = int(index)
+	info["threshold"] = threshold
+	info["total"] = total
+	info["data"] = ted.Serialize()
+	info["checksum"] = checksum
+	return &KeyShare{
+		Dictionary: NewDictionary(info),
+		data:       ted,
+	}
+}
+
+// ParseKeyShare creates key share from the dictionary
+//
+// Returns: nil on invalid share info
+func ParseKeyShare(info any) *KeyShare {
+	if info == nil {
+		return nil
+	} else if share, ok := info.(*KeyShare); ok {
+		return share
+	}
+	dict := FetchMap(info)
+	if dict == nil {
+		return nil
+	} else if ConvertString(dict["scheme"], "") != GF256 {
+		//panic("secret sharing scheme not supported")
+		return nil
+	}
+	share := &KeyShare{
+		Dictionary: NewDictionary(dict),
+		// lazy load
+		data: nil,
+	}
+	index := share.Index()
+	threshold := share.Threshold()
+	total := share.Total()
+	if index < 1 || index > total || threshold < 2 || total < threshold || total > 255 {
+		//panic("key share info error")
+		return nil
+	} else if len(share.Data()) == 0 || share.Checksum() == "" {
+		//panic("key share data not found")
+		return nil
+	}
+	return share
+}
+
+// KeyShare is one of the shares split from a private key
+//
+//	Share Info JSON Format: {
+//	    "scheme"    : "SSS/GF256",
+//	    "index"     : 1,           // share index (x coordinate), 1 ~ total
+//	    "threshold" : 3,           // minimum number of shares to recover the key
+//	    "total"     : 5,           // number of shares
+//	    "data"      : "{BASE64}",  // share data
+//	    "checksum"  : "{HEX}"      // first 4 bytes of SHA-256(secret), same for all shares
+//	}
+type KeyShare struct {
+	*Dictionary
+
+	// data contains the share data in transportable (serializable) format
+	data TransportableData
+}
+
+func (share *KeyShare) Index() int {
+	return share.GetInt("index", 0)
+}
+
+func (share *KeyShare) Threshold() int {
+	return share.GetInt("threshold", 0)
+}
+
+func (share *KeyShare) Total() int {
+	return share.GetInt("total", 0)
+}
+
+func (share *KeyShare) Checksum() string {
+	return share.GetString("checksum", "")
+}
+
+func (share *KeyShare) Data() []byte {
+	ted := share.data
+	if ted == nil {
+		ted = ParseTransportableData(share.Get("data"))
+		if ted == nil {
+			return nil
+		}
+		share.data = ted
+	}
+	return ted.Bytes()
+}
+
+//
+//  Private Key Backup
+//
+
+// SplitPrivateKey splits the private key (serialized from its dictionary)
+// into key shares, any 'threshold' of them can recover the key
+//
+// Returns: nil on invalid parameters
+func SplitPrivateKey(key PrivateKey, threshold, total int) []*KeyShare {
+	secret := UTF8Encode(JSONEncodeMap(key.Map()))
+	defer BytesWipe(secret)
+	shares := Split(secret, threshold, total)
+	if shares == nil {
+		return nil
+	}
+	checksum := secretChecksum(secret)
+	array := make([]*KeyShare, 0, total)
+	for x := 1; x <= total; x++ {
+		data := shares[byte(x)]
+		array = append(array, NewKeyShare(byte(x), threshold, total, data, checksum))
+	}
+	return array
+}
+
+// CombinePrivateKey recovers the private key from the key shares
+//
+// The shares must come from the same split (same threshold, total and checksum),
+// and at least 'threshold' of them with different indexes are required
+//
+// Returns: nil if not enough shares, or the checksum not matched
+func CombinePrivateKey(shares []*KeyShare) PrivateKey {
+	if len(shares) == 0 || shares[0] == nil {
+		return nil
+	}
+	first := shares[0]
+	threshold := first.Threshold()
+	points := make(map[byte][]byte, threshold)
+	for _, share := range shares {
+		if len(points) == threshold {
+			break
+		} else if share == nil {
+			continue
+		} else if share.Threshold() != threshold || share.Total() != first.Total() {
+			//panic("key shares not from the same split")
+			return nil
+		} else if share.Checksum() != first.Checksum() {
+			//panic("key shares not from the same split")
+			return nil
+		}
+		points[byte(share.Index())] = share.Data()
+	}
+	if threshold < 2 || len(points) < threshold {
+		//panic("not enough key shares")
+		return nil
+	}
+	secret := Combine(points)
+	if secret == nil {
+		return nil
+	}
+	defer BytesWipe(secret)
+	if secretChecksum(secret) != first.Checksum() {
+		//panic("key shares checksum not matched")
+		return nil
+	}
+	info := JSONDecodeMap(UTF8Decode(secret))
+	return ParsePrivateKey(info)
+}
+
+func secretChecksum(secret []byte) string {
+	hash := SHA256(secret)
+	return HexEncode(hash[:4])
+}
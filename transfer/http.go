@@ -0,0 +1,259 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	. "github.com/dimchat/mkm-go/types"
+)
+
+//
+//  Resumable upload over HTTP
+//
+//      HEAD  {endpoint}/{name}
+//          <- "Upload-Offset: {stored size}"
+//
+//      PATCH {endpoint}/{name}
+//          -> "Upload-Offset: {offset}", "Upload-Length: {total size}"
+//          <- "Upload-Offset: {stored size}", "Location: {URL}" (when completed)
+//
+//      GET   {endpoint}/{name}
+//          -> "Range: bytes={offset}-" (optional)
+//
+
+func NewHTTPUploader(endpoint string, client *http.Client) *HTTPUploader {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPUploader{
+		Endpoint: strings.TrimRight(endpoint, "/"),
+		Client:   client,
+	}
+}
+
+// HTTPUploader uploads files to the server handled by NewHTTPHandler()
+type HTTPUploader struct {
+	//Uploader
+
+	Endpoint string
+	Client   *http.Client
+}
+
+// Override
+func (uploader *HTTPUploader) Offset(name string) (int64, error) {
+	req, err := http.NewRequest(http.MethodHead, uploader.Endpoint+"/"+name, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := uploader.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, httpError(resp)
+	}
+	return strconv.ParseInt(resp.Header.Get("Upload-Offset"), 10, 64)
+}
+
+// Override
+func (uploader *HTTPUploader) Upload(name string, offset, size int64, body io.Reader) (URL, error) {
+	req, err := http.NewRequest(http.MethodPatch, uploader.Endpoint+"/"+name, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+	req.Header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	req.Header.Set("Upload-Length", strconv.FormatInt(size, 10))
+	resp, err := uploader.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, httpError(resp)
+	}
+	location := resp.Header.Get("Location")
+	if location == "" {
+		// partial file
+		return nil, nil
+	}
+	return ParseURL(location), nil
+}
+
+func NewHTTPDownloader(client *http.Client) *HTTPDownloader {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPDownloader{
+		Client: client,
+	}
+}
+
+// HTTPDownloader downloads files with range requests
+type HTTPDownloader struct {
+	//Downloader
+
+	Client *http.Client
+}
+
+// Override
+func (downloader *HTTPDownloader) Download(url URL, offset int64) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := downloader.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return resp.Body, nil
+	case http.StatusOK:
+		// range not supported, skip the received part
+		if _, err = io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			_ = resp.Body.Close()
+			return nil, err
+		}
+		return resp.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// nothing left after offset when the server tells the file size
+		// is the same: "Content-Range: bytes */{size}"
+		_ = resp.Body.Close()
+		total := resp.Header.Get("Content-Range")
+		if offset > 0 && total == fmt.Sprintf("bytes */%d", offset) {
+			return io.NopCloser(strings.NewReader("")), nil
+		} else if offset > 0 && total == "" {
+			// complete, or beyond the end
+			return nil, ErrSizeUnknown
+		}
+		return nil, ErrOffset
+	}
+	_ = resp.Body.Close()
+	return nil, httpError(resp)
+}
+
+func httpError(resp *http.Response) error {
+	return errors.New("transfer: HTTP " + resp.Status)
+}
+
+//
+//  Server
+//
+
+// NewHTTPHandler serves the files in the store for HTTPUploader & HTTPDownloader,
+// e.g.: httptest.NewServer(NewHTTPHandler(store))
+//
+// The store's BaseURL should be the URL of this handler,
+// so the returned locations can be downloaded from here
+func NewHTTPHandler(store *LocalStore) http.Handler {
+	return &httpHandler{
+		store: store,
+	}
+}
+
+type httpHandler struct {
+	store *LocalStore
+}
+
+// Override
+func (handler *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := path.Base(r.URL.Path)
+	switch r.Method {
+	case http.MethodHead:
+		offset, err := handler.store.Offset(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Upload-Offset", strconv.FormatInt(offset, 10))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPatch:
+		handler.upload(w, r, name)
+	case http.MethodGet:
+		file, err := handler.store.Open(name, 0)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// supports "Range"
+		http.ServeContent(w, r, name, info.ModTime(), file)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (handler *httpHandler) upload(w http.ResponseWriter, r *http.Request, name string) {
+	offset, err1 := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	size, err2 := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if err1 != nil || err2 != nil || offset < 0 || offset > size {
+		http.Error(w, "upload headers error", http.StatusBadRequest)
+		return
+	}
+	body := io.LimitReader(r.Body, size-offset)
+	url, err := handler.store.Upload(name, offset, size, body)
+	if errors.Is(err, ErrOffset) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	} else if errors.Is(err, ErrFileName) || errors.Is(err, os.ErrNotExist) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	stored, _ := handler.store.Offset(name)
+	w.Header().Set("Upload-Offset", strconv.FormatInt(stored, 10))
+	if url == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.Header().Set("Location", url.String())
+	w.WriteHeader(http.StatusCreated)
+}
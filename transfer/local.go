@@ -0,0 +1,187 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package transfer
+
+import (
+	"io"
+	neturl "net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/dimchat/mkm-go/types"
+)
+
+// NewLocalStore creates file store in the directory,
+// the download URLs are "{baseURL}/{name}", default is "file://{root}"
+func NewLocalStore(root, baseURL string) *LocalStore {
+	if baseURL == "" {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			abs = root
+		}
+		baseURL = "file://" + filepath.ToSlash(abs)
+	}
+	return &LocalStore{
+		Root:    root,
+		BaseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// LocalStore keeps uploaded files in a local directory,
+// partial files are stored as "{name}.part" until completed
+type LocalStore struct {
+	//Uploader
+	//Downloader
+
+	Root    string
+	BaseURL string
+}
+
+// protected
+func (store *LocalStore) filePath(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		return "", ErrFileName
+	}
+	return filepath.Join(store.Root, name), nil
+}
+
+// URL returns the download URL for the file name
+func (store *LocalStore) URL(name string) URL {
+	return ParseURL(store.BaseURL + "/" + name)
+}
+
+//-------- Uploader
+
+// Override
+func (store *LocalStore) Offset(name string) (int64, error) {
+	filePath, err := store.filePath(name)
+	if err != nil {
+		return 0, err
+	}
+	if info, err := os.Stat(filePath); err == nil {
+		// completed
+		return info.Size(), nil
+	}
+	if info, err := os.Stat(filePath + ".part"); err == nil {
+		return info.Size(), nil
+	} else if os.IsNotExist(err) {
+		return 0, nil
+	} else {
+		return 0, err
+	}
+}
+
+// Override
+func (store *LocalStore) Upload(name string, offset, size int64, body io.Reader) (URL, error) {
+	filePath, err := store.filePath(name)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(filePath); err == nil && info.Size() == size {
+		// already completed
+		return store.URL(name), nil
+	}
+	if err = os.MkdirAll(store.Root, 0o755); err != nil {
+		return nil, err
+	}
+	partPath := filePath + ".part"
+	stored, err := writePart(partPath, offset, body)
+	if err != nil {
+		return nil, err
+	} else if stored < size {
+		// partial file
+		return nil, nil
+	} else if stored > size {
+		_ = os.Remove(partPath)
+		return nil, ErrOffset
+	}
+	if err = os.Rename(partPath, filePath); err != nil {
+		return nil, err
+	}
+	return store.URL(name), nil
+}
+
+// writePart writes the body into the partial file from offset,
+// returns the new size of the partial file
+func writePart(partPath string, offset int64, body io.Reader) (int64, error) {
+	file, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	} else if offset < 0 || offset > info.Size() {
+		// gap in the file
+		return 0, ErrOffset
+	} else if err = file.Truncate(offset); err != nil {
+		return 0, err
+	} else if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	written, err := io.Copy(file, body)
+	return offset + written, err
+}
+
+//-------- Downloader
+
+// Override
+func (store *LocalStore) Download(url URL, offset int64) (io.ReadCloser, error) {
+	u, err := neturl.Parse(url.String())
+	if err != nil {
+		return nil, err
+	}
+	file, err := store.Open(path.Base(u.Path), offset)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Open opens the completed file from offset
+func (store *LocalStore) Open(name string, offset int64) (*os.File, error) {
+	filePath, err := store.filePath(name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,277 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package transfer
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	. "github.com/dimchat/core-go/format"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/protocol"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/media"
+)
+
+func NewFileTransfer(uploader Uploader, downloader Downloader, cacheDir string) *FileTransfer {
+	return &FileTransfer{
+		Uploader:   uploader,
+		Downloader: downloader,
+		CacheDir:   cacheDir,
+	}
+}
+
+// FileTransfer uploads/downloads the content of PNF (Portable Network File)
+//
+// Uploading: encrypt with the password, upload to get the URL,
+// then put the URL, password & checksum into the PNF.
+//
+// Downloading: download into the cache directory (resuming the partial file),
+// verify the checksum, then decrypt with the password of the PNF
+type FileTransfer struct {
+	Uploader   Uploader
+	Downloader Downloader
+
+	// CacheDir keeps the downloaded files (encrypted)
+	CacheDir string
+}
+
+// UploadFile encrypts & uploads the file data
+//
+// The password itself is never changed: the IV of this file is derived from
+// the password and the data, and the PNF gets a copy of the password carrying
+// that IV, so messages encrypted with the password later will not reuse it.
+//
+// Uploading the same data with the same password again produces the same
+// content & name, so an interrupted upload resumes from the stored size
+//
+// Returns: PNF with URL, filename, password, checksum and file info (size, MIME type, ...)
+func (ft *FileTransfer) UploadFile(data []byte, filename string, password SymmetricKey) (TransportableFile, error) {
+	// 1. encrypt with the password and the IV for this file
+	iv := fileInitVector(password, data)
+	if iv == nil {
+		return nil, ErrEncrypt
+	}
+	params := NewMap()
+	params["IV"] = NewBase64DataWithBytes(iv).Serialize()
+	encrypted := password.Encrypt(data, params)
+	if encrypted == nil {
+		return nil, ErrEncrypt
+	}
+	fileKey := copyKey(password, params)
+	if fileKey == nil {
+		return nil, ErrEncrypt
+	}
+	checksum := sha256.Sum256(encrypted)
+	name := hex.EncodeToString(checksum[:]) + path.Ext(filename)
+	// 2. resume from the stored size
+	size := int64(len(encrypted))
+	offset, err := ft.Uploader.Offset(name)
+	if err != nil {
+		return nil, err
+	} else if offset > size {
+		offset = 0
+	}
+	url, err := ft.Uploader.Upload(name, offset, size, bytes.NewReader(encrypted[offset:]))
+	if err != nil {
+		return nil, err
+	} else if url == nil {
+		return nil, ErrIncomplete
+	}
+	// 3. build PNF
+	pnf := CreateTransportableFile(nil, filename, url, fileKey)
+	SetChecksum(pnf, checksum[:])
+	FillFileInfo(pnf, data)
+	return pnf, nil
+}
+
+// fileInitVector derives the IV from the password and the data:
+//
+//	IV = HMAC-SHA256(password, SHA256(data))[:16]
+//
+// different files get different IVs, the same file always gets the same one
+func fileInitVector(password SymmetricKey, data []byte) []byte {
+	ted := password.Data()
+	if ted == nil || ted.IsEmpty() {
+		return nil
+	}
+	digest := sha256.Sum256(data)
+	mac := hmac.New(sha256.New, ted.Bytes())
+	mac.Write(digest[:])
+	return mac.Sum(nil)[:aes.BlockSize]
+}
+
+// copyKey creates a new key with the info of the password and the params (IV)
+func copyKey(password SymmetricKey, params StringKeyMap) SymmetricKey {
+	info := NewMap()
+	for name, value := range password.Map() {
+		info[name] = value
+	}
+	for name, value := range params {
+		info[name] = value
+	}
+	return ParseSymmetricKey(info)
+}
+
+// DownloadFile downloads & decrypts the file data of the PNF,
+// embedded data is returned directly
+func (ft *FileTransfer) DownloadFile(pnf TransportableFile) ([]byte, error) {
+	if ted := pnf.Data(); ted != nil && !ted.IsEmpty() {
+		return ted.Bytes(), nil
+	}
+	url := pnf.URL()
+	if url == nil {
+		return nil, ErrNoURL
+	}
+	// 1. download into cache
+	cachePath, err := ft.download(url, GetChecksum(pnf))
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, err
+	}
+	// 2. decrypt with the password
+	password := pnf.Password()
+	if password == nil {
+		// plain data
+		return encrypted, nil
+	}
+	data := password.Decrypt(encrypted, password.Map())
+	if data == nil {
+		return nil, ErrDecrypt
+	}
+	return data, nil
+}
+
+// CachePath returns the path of the cached file for the URL
+func (ft *FileTransfer) CachePath(url URL) string {
+	hash := sha256.Sum256([]byte(url.String()))
+	return filepath.Join(ft.CacheDir, hex.EncodeToString(hash[:]))
+}
+
+// download fetches the file into the cache, resuming the partial file;
+// the cached/completed file is verified with the checksum (if given)
+func (ft *FileTransfer) download(url URL, checksum []byte) (string, error) {
+	cachePath := ft.CachePath(url)
+	if _, err := os.Stat(cachePath); err == nil {
+		// cached
+		if ok, err := checkFile(cachePath, checksum); err != nil {
+			return "", err
+		} else if ok {
+			return cachePath, nil
+		}
+		// broken, download again
+		if err = os.Remove(cachePath); err != nil {
+			return "", err
+		}
+	} else if err = os.MkdirAll(ft.CacheDir, 0o755); err != nil {
+		return "", err
+	}
+	partPath := cachePath + ".part"
+	file, err := os.OpenFile(partPath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	// 1. download the rest (nothing when the partial file is complete)
+	body, err := ft.Downloader.Download(url, info.Size())
+	if errors.Is(err, ErrSizeUnknown) && checksum != nil {
+		// let the checksum tell whether the partial file is complete
+		body, err = io.NopCloser(bytes.NewReader(nil)), nil
+	}
+	if errors.Is(err, ErrOffset) {
+		// partial file longer than the remote file, start again next time
+		_ = file.Close()
+		_ = os.Remove(partPath)
+		return "", err
+	} else if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(file, body)
+	_ = body.Close()
+	if err != nil {
+		// keep the partial file for resuming
+		return "", err
+	}
+	// 2. verify
+	if checksum != nil {
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return "", err
+		} else if ok, err := checkSum(file, checksum); err != nil {
+			return "", err
+		} else if !ok {
+			_ = file.Close()
+			_ = os.Remove(partPath)
+			return "", ErrChecksum
+		}
+	}
+	// 3. completed
+	_ = file.Close()
+	if err = os.Rename(partPath, cachePath); err != nil {
+		return "", err
+	}
+	return cachePath, nil
+}
+
+// checkFile verifies the file with the checksum, true when no checksum
+func checkFile(filePath string, checksum []byte) (bool, error) {
+	if checksum == nil {
+		return true, nil
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	return checkSum(file, checksum)
+}
+
+func checkSum(reader io.Reader, checksum []byte) (bool, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return false, err
+	}
+	return bytes.Equal(hash.Sum(nil), checksum), nil
+}
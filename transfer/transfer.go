@@ -0,0 +1,117 @@
+/* license: https://mit-license.org
+ *
+ *  DIMP : Decentralized Instant Messaging Protocol
+ *
+ *                                Written in 2026 by Moky <albert.moky@gmail.com>
+ *
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package transfer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	. "github.com/dimchat/mkm-go/types"
+)
+
+var (
+	ErrFileName   = errors.New("transfer: invalid file name")
+	ErrOffset     = errors.New("transfer: invalid offset")
+	ErrIncomplete = errors.New("transfer: file not complete")
+	ErrNoURL      = errors.New("transfer: file URL not found")
+	ErrChecksum   = errors.New("transfer: checksum mismatch")
+	ErrEncrypt    = errors.New("transfer: failed to encrypt file")
+	ErrDecrypt    = errors.New("transfer: failed to decrypt file")
+
+	// ErrSizeUnknown is an ErrOffset without the remote file size,
+	// the partial file may be complete already
+	ErrSizeUnknown = fmt.Errorf("%w, remote size unknown", ErrOffset)
+)
+
+// Uploader stores file content (encrypted) for others to download
+//
+// Uploading can be resumed: ask the stored size with Offset(),
+// and send the rest of the content from there
+type Uploader interface {
+
+	// Offset returns the size already stored for the file name,
+	// 0 when nothing stored
+	Offset(name string) (int64, error)
+
+	// Upload stores the content from offset, 'size' is the total length
+	//
+	// Returns: the download URL when the file is complete, nil for a partial file
+	Upload(name string, offset, size int64, body io.Reader) (URL, error)
+}
+
+// Downloader fetches file content from the URL
+type Downloader interface {
+
+	// Download returns the content from offset (to resume a partial download),
+	// empty content when offset is the end of the file,
+	// ErrOffset when offset is beyond the end,
+	// ErrSizeUnknown when it cannot tell which one
+	Download(url URL, offset int64) (io.ReadCloser, error)
+}
+
+//
+//  Checksum
+//
+
+// SetChecksum sets the SHA-256 of the uploaded content (hex) into the PNF
+//
+//	PNF JSON Format: {
+//	    "URL"      : "https://...",
+//	    "filename" : "avatar.png",
+//	    "key"      : { ... },      // password (DecryptKey)
+//	    "checksum" : "{HEX}"       // SHA-256 of the (encrypted) content
+//	}
+func SetChecksum(file Mapper, checksum []byte) {
+	if checksum == nil {
+		file.Remove("checksum")
+	} else {
+		file.Set("checksum", hex.EncodeToString(checksum))
+	}
+}
+
+// GetChecksum gets the SHA-256 of the uploaded content from the PNF
+//
+// Returns: nil if not found
+func GetChecksum(file Mapper) []byte {
+	text := ConvertString(file.Get("checksum"), "")
+	if text == "" {
+		return nil
+	}
+	checksum, err := hex.DecodeString(text)
+	if err != nil || len(checksum) != sha256.Size {
+		//panic("PNF checksum error")
+		return nil
+	}
+	return checksum
+}
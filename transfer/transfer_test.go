@@ -0,0 +1,183 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package transfer_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dimchat/plugins-go/ext"
+
+	. "github.com/dimchat/core-go/protocol"
+	. "github.com/dimchat/mkm-go/crypto"
+	. "github.com/dimchat/mkm-go/protocol"
+	. "github.com/dimchat/mkm-go/types"
+	. "github.com/dimchat/plugins-go/transfer"
+)
+
+func init() {
+	ext.ExtensionLoader{}.Load()
+	ext.PluginLoader{}.Load()
+}
+
+var errInterrupted = errors.New("connection lost")
+
+// flakyUploader stores only 'limit' bytes of the first upload, then fails
+type flakyUploader struct {
+	*LocalStore
+
+	limit   int64
+	names   []string
+	offsets []int64
+}
+
+func (uploader *flakyUploader) Upload(name string, offset, size int64, body io.Reader) (URL, error) {
+	uploader.names = append(uploader.names, name)
+	uploader.offsets = append(uploader.offsets, offset)
+	if uploader.limit > 0 {
+		_, _ = uploader.LocalStore.Upload(name, offset, size, io.LimitReader(body, uploader.limit))
+		uploader.limit = 0
+		return nil, errInterrupted
+	}
+	return uploader.LocalStore.Upload(name, offset, size, body)
+}
+
+func fileData() []byte {
+	return []byte(strings.Repeat("PNF file content. ", 1000))
+}
+
+func TestResumeUpload(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalStore(filepath.Join(dir, "store"), "")
+	uploader := &flakyUploader{LocalStore: store, limit: 5000}
+	ft := NewFileTransfer(uploader, store, filepath.Join(dir, "cache"))
+	data := fileData()
+	password := GenerateSymmetricKey(AES)
+
+	// 1. interrupted
+	if _, err := ft.UploadFile(data, "a.txt", password); err != errInterrupted {
+		t.Fatalf("upload not interrupted: %v", err)
+	}
+	// 2. resumed with the same name from the stored size
+	pnf, err := ft.UploadFile(data, "a.txt", password)
+	if err != nil || pnf == nil {
+		t.Fatalf("failed to resume: %v", err)
+	}
+	if len(uploader.names) != 2 || uploader.names[0] != uploader.names[1] {
+		t.Errorf("file names changed: %v", uploader.names)
+	}
+	if uploader.offsets[0] != 0 || uploader.offsets[1] != 5000 {
+		t.Errorf("offsets: %v", uploader.offsets)
+	}
+	// the password is not changed
+	if password.Get("IV") != nil || password.Get("iv") != nil {
+		t.Errorf("password changed: %v", password.Map())
+	}
+	// 3. download & decrypt
+	out, err := ft.DownloadFile(pnf)
+	if err != nil || !bytes.Equal(out, data) {
+		t.Errorf("failed to download: %v", err)
+	}
+	// another file gets another IV
+	other, err := ft.UploadFile(append(data, '!'), "b.txt", password)
+	if err != nil || other.Password().Get("IV") == pnf.Password().Get("IV") {
+		t.Errorf("IV reused: %v", err)
+	}
+}
+
+func TestDownloadCacheChecksum(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalStore(filepath.Join(dir, "store"), "")
+	ft := NewFileTransfer(store, store, filepath.Join(dir, "cache"))
+	data := fileData()
+	pnf, err := ft.UploadFile(data, "a.txt", GenerateSymmetricKey(AES))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := ft.DownloadFile(pnf); err != nil || !bytes.Equal(out, data) {
+		t.Fatalf("failed to download: %v", err)
+	}
+	// broken cache is evicted and downloaded again
+	cachePath := ft.CachePath(pnf.URL())
+	if err = os.WriteFile(cachePath, []byte("broken"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := ft.DownloadFile(pnf); err != nil || !bytes.Equal(out, data) {
+		t.Errorf("broken cache returned: %v", err)
+	}
+}
+
+func TestDownloadRangeNotSatisfiable(t *testing.T) {
+	data := fileData()
+	// server refusing the range without 'Content-Range'
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+	url := ParseURL(server.URL + "/a.txt")
+	ft := NewFileTransfer(nil, NewHTTPDownloader(server.Client()), t.TempDir())
+	partPath := ft.CachePath(url) + ".part"
+
+	// complete partial file, verified by the checksum
+	pnf := CreateTransportableFile(nil, "a.txt", url, nil)
+	checksum := sha256.Sum256(data)
+	SetChecksum(pnf, checksum[:])
+	if err := os.WriteFile(partPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := ft.DownloadFile(pnf); err != nil || !bytes.Equal(out, data) {
+		t.Errorf("complete partial file rejected: %v", err)
+	}
+	// without checksum, it cannot tell
+	url = ParseURL(server.URL + "/b.txt")
+	pnf = CreateTransportableFile(nil, "b.txt", url, nil)
+	partPath = ft.CachePath(url) + ".part"
+	if err := os.WriteFile(partPath, data[:100], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ft.DownloadFile(pnf); !errors.Is(err, ErrOffset) {
+		t.Errorf("unverified partial file accepted: %v", err)
+	}
+	if _, err := os.Stat(partPath); !os.IsNotExist(err) {
+		t.Errorf("partial file not removed: %v", err)
+	}
+	// starts again
+	if out, err := ft.DownloadFile(pnf); err != nil || !bytes.Equal(out, data) {
+		t.Errorf("failed to download again: %v", err)
+	}
+}
@@ -75,6 +75,15 @@ func BytesSplit(data []byte, width int) [][]byte {
 	return chunks
 }
 
+// BytesWipe overwrites the data with zeros in place
+//
+// Used for erasing secret key material from memory when the owner is destroyed
+func BytesWipe(data []byte) {
+	for index := range data {
+		data[index] = 0
+	}
+}
+
 func RandomBytes(size uint) []byte {
 	seed := TimestampNano(TimeNow())
 	random := rand.New(rand.NewSource(seed))
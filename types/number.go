@@ -0,0 +1,140 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+
+	. "github.com/dimchat/mkm-go/types"
+)
+
+func NewNumberConverter() Converter {
+	return &NumberConverter{}
+}
+
+// NumberConverter extends the default converter with json.Number,
+// which is kept by the lossless JSON coder for the numbers
+// that cannot be stored exactly as int64/uint64
+type NumberConverter struct {
+	//Converter
+	DataConverter
+}
+
+// unwrapNumber converts json.Number to int64, uint64 or float64
+func unwrapNumber(value any) any {
+	if number, ok := value.(json.Number); ok {
+		str := string(number)
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return i
+		} else if u, err := strconv.ParseUint(str, 10, 64); err == nil {
+			return u
+		} else if f, err := strconv.ParseFloat(str, 64); err == nil {
+			return f
+		}
+		return str
+	}
+	return value
+}
+
+// Override
+func (conv NumberConverter) GetString(value any, defaultValue string) string {
+	if number, ok := value.(json.Number); ok {
+		// keep the original text
+		return conv.DataConverter.GetString(string(number), defaultValue)
+	}
+	return conv.DataConverter.GetString(value, defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetBool(value any, defaultValue bool) bool {
+	return conv.DataConverter.GetBool(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetInt(value any, defaultValue int) int {
+	return conv.DataConverter.GetInt(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetInt8(value any, defaultValue int8) int8 {
+	return conv.DataConverter.GetInt8(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetInt16(value any, defaultValue int16) int16 {
+	return conv.DataConverter.GetInt16(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetInt32(value any, defaultValue int32) int32 {
+	return conv.DataConverter.GetInt32(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetInt64(value any, defaultValue int64) int64 {
+	return conv.DataConverter.GetInt64(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetUInt(value any, defaultValue uint) uint {
+	return conv.DataConverter.GetUInt(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetUInt8(value any, defaultValue uint8) uint8 {
+	return conv.DataConverter.GetUInt8(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetUInt16(value any, defaultValue uint16) uint16 {
+	return conv.DataConverter.GetUInt16(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetUInt32(value any, defaultValue uint32) uint32 {
+	return conv.DataConverter.GetUInt32(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetUInt64(value any, defaultValue uint64) uint64 {
+	return conv.DataConverter.GetUInt64(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetFloat32(value any, defaultValue float32) float32 {
+	return conv.DataConverter.GetFloat32(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetFloat64(value any, defaultValue float64) float64 {
+	return conv.DataConverter.GetFloat64(unwrapNumber(value), defaultValue)
+}
+
+// Override
+func (conv NumberConverter) GetTime(value any, defaultValue Time) Time {
+	return conv.DataConverter.GetTime(unwrapNumber(value), defaultValue)
+}
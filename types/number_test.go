@@ -0,0 +1,61 @@
+/* license: https://mit-license.org
+ * ==============================================================================
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2026 Albert Moky
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ * ==============================================================================
+ */
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNumberConverter(t *testing.T) {
+	conv := NewNumberConverter()
+	if v := conv.GetInt64(json.Number("9007199254740993"), 0); v != 9007199254740993 {
+		t.Errorf("GetInt64() = %d", v)
+	}
+	if v := conv.GetUInt64(json.Number("18446744073709551615"), 0); v != 18446744073709551615 {
+		t.Errorf("GetUInt64() = %d", v)
+	}
+	if v := conv.GetUInt(json.Number("42"), 0); v != 42 {
+		t.Errorf("GetUInt() = %d", v)
+	}
+	if v := conv.GetFloat64(json.Number("1.5"), 0); v != 1.5 {
+		t.Errorf("GetFloat64() = %v", v)
+	}
+	// the original text is kept for strings
+	if v := conv.GetString(json.Number("123456789012345678901234567890"), ""); v != "123456789012345678901234567890" {
+		t.Errorf("GetString() = %s", v)
+	}
+	if v := conv.GetBool(json.Number("1"), false); !v {
+		t.Errorf("GetBool() = %v", v)
+	}
+	// other values work as before
+	if v := conv.GetInt64(int64(-1), 0); v != -1 {
+		t.Errorf("GetInt64(int64) = %d", v)
+	}
+	if v := conv.GetString("hello", ""); v != "hello" {
+		t.Errorf("GetString(string) = %s", v)
+	}
+}